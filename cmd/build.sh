@@ -1,7 +1,7 @@
 #!  /usr/bin/ksh
 
-#   build go-dmig cli
+#   build go-dmig cli; the database drivers are given as build tags, like: build.sh "postgres mysql"
 export  CURRENT_DIR="$( pwd )"
 
-go build -o bin/go-dmig main.go
+go build -tags "$1" -o bin/go-dmig .
 cd ${CURRENT_DIR}
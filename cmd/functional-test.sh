@@ -22,3 +22,27 @@ echo "[scenario #${SCENARIO}] ${DESCRIPTION}"
 cd "test/scenario${SCENARIO}"
 ../../bin/go-dmig config.yaml
 cd ${CURRENT_DIR}
+
+#   test scenatio #03
+export SCENARIO="03"
+export DESCRIPTION="SQL script output format"
+
+echo
+echo "[scenario #${SCENARIO}] ${DESCRIPTION}"
+
+cd "test/scenario${SCENARIO}"
+../../bin/go-dmig config.yaml
+cat output_03.sql
+cd ${CURRENT_DIR}
+
+#   test scenatio #04
+export SCENARIO="04"
+export DESCRIPTION="join of two inputs"
+
+echo
+echo "[scenario #${SCENARIO}] ${DESCRIPTION}"
+
+cd "test/scenario${SCENARIO}"
+../../bin/go-dmig config.yaml
+cat output_04.sql
+cd ${CURRENT_DIR}
@@ -0,0 +1,14 @@
+//go:build mysql
+// +build mysql
+
+///////////////////////////////////////////////////////////////////////////////
+//	driverMySQL.go  -  Oct-19-2026  -  aldebap
+//
+//	MySQL driver, registered as mysql, built with -tags mysql
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	_ "github.com/go-sql-driver/mysql"
+)
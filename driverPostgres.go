@@ -0,0 +1,14 @@
+//go:build postgres
+// +build postgres
+
+///////////////////////////////////////////////////////////////////////////////
+//	driverPostgres.go  -  Oct-19-2026  -  aldebap
+//
+//	PostgreSQL driver, registered as postgres, built with -tags postgres
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	_ "github.com/lib/pq"
+)
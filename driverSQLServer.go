@@ -0,0 +1,14 @@
+//go:build sqlserver
+// +build sqlserver
+
+///////////////////////////////////////////////////////////////////////////////
+//	driverSQLServer.go  -  Oct-19-2026  -  aldebap
+//
+//	SQL Server driver, registered as sqlserver, built with -tags sqlserver
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	_ "github.com/microsoft/go-mssqldb"
+)
@@ -0,0 +1,14 @@
+//go:build sqlite
+// +build sqlite
+
+///////////////////////////////////////////////////////////////////////////////
+//	driverSQLite.go  -  Oct-19-2026  -  aldebap
+//
+//	SQLite driver, registered as sqlite3, built with -tags sqlite (it needs cgo)
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	_ "github.com/mattn/go-sqlite3"
+)
@@ -3,9 +3,18 @@ module github.com/aldebap/go-dmig
 go 1.17
 
 require (
+	github.com/golang-sql/civil v0.0.0-20190719163853-cb61b32ac6fe // indirect
+	github.com/golang-sql/sqlexp v0.1.0 // indirect
+	golang.org/x/crypto v0.0.0-20220525230936-793ad666bf5e // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-require github.com/aldebap/go-dmig/migration v0.0.0-unpublished
+require (
+	github.com/aldebap/go-dmig/migration v0.0.0-unpublished
+	github.com/go-sql-driver/mysql v1.7.1
+	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.16
+	github.com/microsoft/go-mssqldb v0.17.0
+)
 
 replace github.com/aldebap/go-dmig/migration v0.0.0-unpublished => ./migration
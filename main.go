@@ -8,9 +8,12 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	migration "github.com/aldebap/go-dmig/migration"
 )
@@ -23,10 +26,12 @@ const (
 func main() {
 	var (
 		version bool
+		resume  bool
 	)
 
 	//	CLI arguments
 	flag.BoolVar(&version, "version", false, "show Go-DMig version")
+	flag.BoolVar(&resume, "resume", false, "resume failed jobs from their last checkpoint")
 
 	flag.Parse()
 
@@ -59,9 +64,31 @@ func main() {
 	}
 
 	//	execute the migration
-	err = dmig.PerformMigration()
+	dmig.Resume = resume
+
+	err = dmig.PerformMigration(interruptContext())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "[error] fail performing data migration: %s\n", err.Error())
 		os.Exit(-1)
 	}
 }
+
+//	interruptContext create a context cancelled by the first SIGINT or SIGTERM, so the running jobs can stop reading
+//	and finish their outputs; a second signal terminates the process right away
+func interruptContext() context.Context {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		received := <-signals
+		signal.Stop(signals)
+
+		fmt.Fprintf(os.Stderr, "[warning] %s received: stopping the migration (send it again to abort)\n", received)
+		cancel()
+	}()
+
+	return ctx
+}
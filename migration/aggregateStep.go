@@ -0,0 +1,505 @@
+///////////////////////////////////////////////////////////////////////////////
+//	aggregateStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that summarizes rows by groups of key fields
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//	constants for aggregate functions
+const (
+	AGGREGATE_COUNT          = 1
+	AGGREGATE_SUM            = 2
+	AGGREGATE_MIN            = 3
+	AGGREGATE_MAX            = 4
+	AGGREGATE_AVG            = 5
+	AGGREGATE_COUNT_DISTINCT = 6
+	AGGREGATE_FIRST          = 7
+	AGGREGATE_LAST           = 8
+)
+
+var (
+	aggregate_function = map[string]uint8{
+		"count":          AGGREGATE_COUNT,
+		"sum":            AGGREGATE_SUM,
+		"min":            AGGREGATE_MIN,
+		"max":            AGGREGATE_MAX,
+		"avg":            AGGREGATE_AVG,
+		"count_distinct": AGGREGATE_COUNT_DISTINCT,
+		"first":          AGGREGATE_FIRST,
+		"last":           AGGREGATE_LAST,
+	}
+)
+
+//	constants for aggregate modes
+const (
+	AGGREGATE_HASH   = 1
+	AGGREGATE_STREAM = 2
+)
+
+var (
+	aggregate_mode = map[string]uint8{
+		"hash":   AGGREGATE_HASH,
+		"stream": AGGREGATE_STREAM,
+	}
+)
+
+//	attributes for an aggregated field
+type AggregateField struct {
+	Name     string `yaml:"name"`
+	Function string `yaml:"function"`
+	Field    string `yaml:"field"`
+}
+
+//	attributes for an aggregate step configuration
+type AggregateConfig struct {
+	Mode      string           `yaml:"mode"`
+	GroupBy   []string         `yaml:"group_by"`
+	FieldList []AggregateField `yaml:"fields"`
+}
+
+//	attributes of an aggregated value being computed for a group
+type aggregateValue struct {
+	count      int64
+	intSum     int64
+	decimalSum Decimal
+	isDecimal  bool
+	value      interface{}
+	hasValue   bool
+	distinct   map[string]bool
+}
+
+//	attributes of a group of rows; the group row is rejected with the position of its first row
+type aggregateGroup struct {
+	source     string
+	lineNumber int64
+	keyValues  []interface{}
+	valueList  []*aggregateValue
+}
+
+//	attributes for an aggregate step
+type aggregateStep struct {
+	Config AggregateConfig
+
+	NextStep DataPipelineStep
+
+	mode          uint8
+	functionList  []uint8
+	fieldTypes    map[string]uint8
+	ctx           context.Context
+	rejecter      RowRejecter
+	groupIndex    map[string]*aggregateGroup
+	groupList     []*aggregateGroup
+	currentGroup  *aggregateGroup
+	rowsProcessed int64
+	groupsEmitted int64
+}
+
+//	NewAggregateStep create a new aggregateStep, given the group and aggregated fields and the input fields
+func NewAggregateStep(config AggregateConfig, inputFieldList []DataField) (DataPipelineStep, error) {
+
+	if len(config.Mode) == 0 {
+		config.Mode = "hash"
+	}
+
+	mode, found := aggregate_mode[config.Mode]
+	if !found {
+		return nil, errors.New("Invalid aggregate mode: " + config.Mode)
+	}
+
+	if len(config.FieldList) == 0 {
+		return nil, errors.New("Aggregate step need at least one field")
+	}
+
+	step := &aggregateStep{
+		Config:     config,
+		mode:       mode,
+		fieldTypes: make(map[string]uint8),
+		groupIndex: make(map[string]*aggregateGroup),
+	}
+
+	for _, field := range inputFieldList {
+		step.fieldTypes[field.Name] = data_field_type[field.Type]
+	}
+
+	for _, field := range config.FieldList {
+		if len(field.Name) == 0 {
+			return nil, errors.New("Missing aggregate field name")
+		}
+
+		function, found := aggregate_function[field.Function]
+		if !found {
+			return nil, errors.New("Invalid aggregate function: " + field.Function)
+		}
+
+		//	only count can be used without a field, to count the rows
+		if len(field.Field) == 0 && function != AGGREGATE_COUNT {
+			return nil, errors.New("Missing field for aggregate function " + field.Function + ": " + field.Name)
+		}
+
+		step.functionList = append(step.functionList, function)
+	}
+
+	return step, nil
+}
+
+//	newAggregateStepFromConfig create a new aggregateStep from a job step configuration
+func newAggregateStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	var stepConfig AggregateConfig
+
+	err := config.Decode(&stepConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAggregateStep(stepConfig, job.Job.Input.FieldList)
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *aggregateStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *aggregateStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow add the row to its group; in stream mode, a group is sent to the next step as soon as its key changes
+func (s *aggregateStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	//	numbers are checked before the row changes any group, so an invalid row can be rejected
+	err = s.checkNumbers(row)
+	if err != nil {
+		return false, err
+	}
+
+	key := joinKeyValues(row, s.Config.GroupBy)
+
+	var group *aggregateGroup
+	var previousGroup *aggregateGroup
+
+	if s.mode == AGGREGATE_HASH {
+		groupKey := strings.Join(key, lookupKeySeparator)
+
+		group = s.groupIndex[groupKey]
+		if group == nil {
+			group = s.newGroup(row)
+			s.groupIndex[groupKey] = group
+			s.groupList = append(s.groupList, group)
+		}
+	} else {
+		group = s.currentGroup
+
+		if group != nil {
+			result := s.compareGroupKeys(keyFieldValues(row, s.Config.GroupBy), group.keyValues)
+			if result < 0 {
+				return false, NewFatalError(errors.New("Aggregate input not sorted by group fields: " + strings.Join(key, ", ")))
+			}
+
+			if result > 0 {
+				previousGroup = group
+				group = nil
+			}
+		}
+
+		if group == nil {
+			group = s.newGroup(row)
+			s.currentGroup = group
+		}
+	}
+
+	err = s.addRow(group, row)
+	if err != nil {
+		if previousGroup != nil {
+			s.currentGroup = previousGroup
+		}
+		return false, err
+	}
+	s.rowsProcessed++
+
+	//	the previous group is only sent after the row starts the new one, so an error from the next step
+	//	doesn't send the previous group again or drop the row; the group row itself is rejected, and a fatal
+	//	error keeps the group's position
+	if previousGroup != nil {
+		err = s.emitGroup(previousGroup)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+//	Start keep the job's rejecter for the group rows; groups are created as their rows are found
+func (s *aggregateStep) Start(job *JobContext) error {
+
+	s.ctx = contextOf(job)
+
+	s.rejecter = nil
+	if job != nil {
+		s.rejecter = job.Rejecter
+	}
+
+	return nil
+}
+
+//	Finish send the groups not yet sent to the next step; when the job failed, they are discarded
+func (s *aggregateStep) Finish(jobErr error) error {
+
+	if jobErr != nil {
+		s.currentGroup = nil
+		s.groupList = nil
+		s.groupIndex = make(map[string]*aggregateGroup)
+
+		return nil
+	}
+
+	if s.mode == AGGREGATE_STREAM {
+		if s.currentGroup == nil {
+			return nil
+		}
+
+		err := checkInterrupted(s.ctx)
+		if err == nil {
+			err = s.emitGroup(s.currentGroup)
+		}
+		s.currentGroup = nil
+
+		return err
+	}
+
+	defer func() {
+		s.groupList = nil
+		s.groupIndex = make(map[string]*aggregateGroup)
+	}()
+
+	for _, group := range s.groupList {
+		err := checkInterrupted(s.ctx)
+		if err != nil {
+			return err
+		}
+
+		err = s.emitGroup(group)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//	Summary report the number of rows aggregated and groups generated
+func (s *aggregateStep) Summary() string {
+
+	return fmt.Sprintf("Aggregate %s: %d rows aggregated into %d groups",
+		strings.Join(s.Config.GroupBy, ", "), s.rowsProcessed, s.groupsEmitted)
+}
+
+//	newGroup create a new group of rows, keeping the group fields values of its first row
+func (s *aggregateStep) newGroup(row *Row) *aggregateGroup {
+
+	group := &aggregateGroup{
+		source:     row.Source,
+		lineNumber: row.LineNumber,
+		keyValues:  keyFieldValues(row, s.Config.GroupBy),
+	}
+
+	for _, function := range s.functionList {
+		value := &aggregateValue{}
+		if function == AGGREGATE_COUNT_DISTINCT {
+			value.distinct = make(map[string]bool)
+		}
+
+		group.valueList = append(group.valueList, value)
+	}
+
+	return group
+}
+
+//	compareGroupKeys compare two group keys field by field
+func (s *aggregateStep) compareGroupKeys(key1 []interface{}, key2 []interface{}) int {
+
+	for i, field := range s.Config.GroupBy {
+		result := compareRowValues(key1[i], key2[i], s.fieldTypes[field])
+		if result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+//	checkNumbers check the values of the fields added by sum and avg
+func (s *aggregateStep) checkNumbers(row *Row) error {
+
+	for i, field := range s.Config.FieldList {
+		if s.functionList[i] != AGGREGATE_SUM && s.functionList[i] != AGGREGATE_AVG {
+			continue
+		}
+
+		if row.Get(field.Field) == nil {
+			continue
+		}
+
+		fieldValue := strings.TrimSpace(row.GetString(field.Field))
+
+		_, err := ParseDecimal(fieldValue)
+		if err != nil {
+			return errors.New("Invalid number for aggregate field " + field.Field + ": " + fieldValue)
+		}
+	}
+
+	return nil
+}
+
+//	addRow add the row values to the group's aggregated values; nulls are ignored, except for first and last
+func (s *aggregateStep) addRow(group *aggregateGroup, row *Row) error {
+
+	for i, field := range s.Config.FieldList {
+		value := group.valueList[i]
+		function := s.functionList[i]
+
+		rowValue := row.Get(field.Field)
+		fieldValue := strings.TrimSpace(row.GetString(field.Field))
+
+		switch function {
+		case AGGREGATE_FIRST:
+			if !value.hasValue {
+				value.value = rowValue
+				value.hasValue = true
+			}
+			continue
+
+		case AGGREGATE_LAST:
+			value.value = rowValue
+			value.hasValue = true
+			continue
+		}
+
+		//	count without a field counts the rows
+		if len(field.Field) == 0 {
+			value.count++
+			continue
+		}
+
+		if rowValue == nil {
+			continue
+		}
+		value.count++
+
+		switch function {
+		case AGGREGATE_SUM, AGGREGATE_AVG:
+			err := value.add(field.Field, fieldValue)
+			if err != nil {
+				return err
+			}
+
+		case AGGREGATE_MIN:
+			if !value.hasValue || compareRowValues(rowValue, value.value, s.fieldTypes[field.Field]) < 0 {
+				value.value = rowValue
+				value.hasValue = true
+			}
+
+		case AGGREGATE_MAX:
+			if !value.hasValue || compareRowValues(rowValue, value.value, s.fieldTypes[field.Field]) > 0 {
+				value.value = rowValue
+				value.hasValue = true
+			}
+
+		case AGGREGATE_COUNT_DISTINCT:
+			value.distinct[fieldValue] = true
+		}
+	}
+
+	return nil
+}
+
+//	add add a number to the sum, switching to a decimal sum when a decimal number is found, or when the sum
+//	doesn't fit in an integer
+func (v *aggregateValue) add(field string, fieldValue string) error {
+
+	if !v.isDecimal {
+		number, err := strconv.ParseInt(fieldValue, 10, 64)
+		if err == nil {
+			sum, fits := addInt64(v.intSum, number)
+			if fits {
+				v.intSum = sum
+				return nil
+			}
+		}
+	}
+
+	number, err := ParseDecimal(fieldValue)
+	if err != nil {
+		return errors.New("Invalid number for aggregate field " + field + ": " + fieldValue)
+	}
+
+	if !v.isDecimal {
+		v.isDecimal = true
+		v.decimalSum = NewDecimal(v.intSum)
+	}
+	v.decimalSum = v.decimalSum.Add(number)
+
+	return nil
+}
+
+//	result get the aggregated value for a function; sum and avg are null when there are no values
+func (v *aggregateValue) result(function uint8) interface{} {
+
+	switch function {
+	case AGGREGATE_COUNT:
+		return v.count
+
+	case AGGREGATE_COUNT_DISTINCT:
+		return int64(len(v.distinct))
+
+	case AGGREGATE_SUM:
+		if v.count == 0 {
+			return nil
+		}
+		if v.isDecimal {
+			return v.decimalSum
+		}
+		return v.intSum
+
+	case AGGREGATE_AVG:
+		if v.count == 0 {
+			return nil
+		}
+		if v.isDecimal {
+			return v.decimalSum.Quo(NewDecimal(v.count))
+		}
+		return NewDecimal(v.intSum).Quo(NewDecimal(v.count))
+	}
+
+	return v.value
+}
+
+//	emitGroup send a row with the group fields and the aggregated values to the next step; a row level error
+//	rejects the group row
+func (s *aggregateStep) emitGroup(group *aggregateGroup) error {
+
+	row := NewRow()
+	row.Source = group.source
+	row.LineNumber = group.lineNumber
+
+	for i, field := range s.Config.GroupBy {
+		row.Set(field, group.keyValues[i])
+	}
+	for i, field := range s.Config.FieldList {
+		row.Set(field.Name, group.valueList[i].result(s.functionList[i]))
+	}
+	s.groupsEmitted++
+
+	return forwardRow(s.NextStep, s.rejecter, row)
+}
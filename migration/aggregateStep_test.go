@@ -0,0 +1,370 @@
+///////////////////////////////////////////////////////////////////////////////
+//	aggregateStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the aggregate pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+//	Test_AggregateStep_ProcessRow test cases for the aggregate step
+func Test_AggregateStep_ProcessRow(t *testing.T) {
+
+	inputFieldList := []DataField{
+		{Name: "branch", Type: "integer"},
+		{Name: "customer", Type: "string"},
+		{Name: "amount", Type: "integer"},
+	}
+
+	sortedRows := []map[string]string{
+		{"branch": "2", "customer": "ANN", "amount": "100"},
+		{"branch": "2", "customer": "BOB", "amount": "50"},
+		{"branch": "2", "customer": "ANN", "amount": ""},
+		{"branch": "10", "customer": "CARL", "amount": "7"},
+	}
+
+	unsortedRows := []map[string]string{
+		{"branch": "10", "customer": "CARL", "amount": "7"},
+		{"branch": "2", "customer": "ANN", "amount": "100"},
+		{"branch": "2", "customer": "BOB", "amount": "50"},
+		{"branch": "2", "customer": "ANN", "amount": ""},
+	}
+
+	fieldList := []AggregateField{
+		{Name: "rows", Function: "count"},
+		{Name: "amounts", Function: "count", Field: "amount"},
+		{Name: "customers", Function: "count_distinct", Field: "customer"},
+		{Name: "total", Function: "sum", Field: "amount"},
+		{Name: "average", Function: "avg", Field: "amount"},
+		{Name: "lowest", Function: "min", Field: "amount"},
+		{Name: "highest", Function: "max", Field: "amount"},
+		{Name: "first_customer", Function: "first", Field: "customer"},
+		{Name: "last_customer", Function: "last", Field: "customer"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		mode     string
+		input    []map[string]string
+		output   string
+	}{
+		{scenario: "hash mode", mode: "hash", input: unsortedRows,
+			output: "10:1:1:1:7:7:7:7:CARL:CARL 2:3:2:2:150:75:50:100:ANN:ANN"},
+		{scenario: "stream mode", mode: "stream", input: sortedRows,
+			output: "2:3:2:2:150:75:50:100:ANN:ANN 10:1:1:1:7:7:7:7:CARL:CARL"},
+		{scenario: "stream mode with unsorted input", mode: "stream", input: unsortedRows,
+			output: "Aggregate input not sorted by group fields: 2"},
+	}
+
+	t.Run(">>> validation of aggregate step", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			collector := &rowCollector{}
+
+			step, err := NewAggregateStep(AggregateConfig{Mode: test.mode, GroupBy: []string{"branch"}, FieldList: fieldList}, inputFieldList)
+			if err != nil {
+				t.Errorf("unexpected error in NewAggregateStep(): %s", err)
+				continue
+			}
+			step.SetNextStep(collector)
+
+			for _, row := range test.input {
+				_, err = step.ProcessRow(newInputTestRow(row, inputFieldList))
+				if err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = finishPipeline(step, nil)
+			}
+
+			got := ""
+			want := test.output
+
+			if err != nil {
+				got = err.Error()
+			} else {
+				var groupList []string
+
+				for _, row := range collector.rows {
+					group := row.GetString("branch")
+					for _, field := range fieldList {
+						group += ":" + row.GetString(field.Name)
+					}
+					groupList = append(groupList, group)
+				}
+				got = strings.Join(groupList, " ")
+			}
+
+			if want != got {
+				t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+
+	t.Run(">>> validation of stream mode with a rejected group", func(t *testing.T) {
+
+		streamFieldList := []DataField{
+			{Name: "group", Type: "string"},
+			{Name: "amount", Type: "integer"},
+		}
+
+		var emitted []string
+		var rejected bool
+
+		sink := &rowCopyStep{
+			process: func(row *Row) error {
+				emitted = append(emitted, row.GetString("group")+"="+row.GetString("total"))
+				if row.GetString("group") == "A" && !rejected {
+					rejected = true
+					return errors.New("Rejected group: A")
+				}
+				return nil
+			},
+		}
+
+		step, err := NewAggregateStep(AggregateConfig{Mode: "stream", GroupBy: []string{"group"}, FieldList: []AggregateField{
+			{Name: "total", Function: "sum", Field: "amount"},
+		}}, streamFieldList)
+		if err != nil {
+			t.Errorf("unexpected error in NewAggregateStep(): %s", err)
+			return
+		}
+		step.SetNextStep(sink)
+
+		//	the group row the next step fails to process is rejected, not the input row that closed the group
+		rejects := &rowRejecterMock{}
+
+		err = startPipeline(step, &JobContext{Rejecter: rejects})
+		if err != nil {
+			t.Errorf("unexpected error in startPipeline(): %s", err)
+		}
+
+		var errList []string
+
+		for i, row := range [][2]string{{"A", "1"}, {"B", "2"}, {"B", "3"}} {
+			inputRow := newInputTestRow(map[string]string{"group": row[0], "amount": row[1]}, streamFieldList)
+			inputRow.LineNumber = int64(i + 1)
+
+			processed, err := step.ProcessRow(inputRow)
+			if err != nil {
+				errList = append(errList, err.Error())
+			}
+			if !processed {
+				t.Errorf("fail in ProcessRow(): row not processed: %v", row)
+			}
+		}
+
+		err = finishPipeline(step, nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		want := "A=1 B=5 |  | Rejected group: A"
+		got := strings.Join(emitted, " ") + " | " + strings.Join(errList, ", ") + " | " + strings.Join(rejects.errList, ", ")
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+		if fmt.Sprint(rejects.lineList) != "[1]" {
+			t.Errorf("fail in ProcessRow(): expected the group rejected at line 1, result: %v", rejects.lineList)
+		}
+	})
+
+	t.Run(">>> validation of derived typed values", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		step, err := NewAggregateStep(AggregateConfig{Mode: "stream", GroupBy: []string{"day"}, FieldList: []AggregateField{
+			{Name: "lowest", Function: "min", Field: "amount"},
+			{Name: "highest", Function: "max", Field: "amount"},
+		}}, nil)
+		if err != nil {
+			t.Errorf("unexpected error in NewAggregateStep(): %s", err)
+			return
+		}
+		step.SetNextStep(collector)
+
+		for _, day := range []int{9, 10} {
+			for _, amount := range []int64{10, 2, 9} {
+				row := NewRow()
+				row.Set("day", time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC))
+				row.Set("amount", amount*int64(day))
+
+				_, err = step.ProcessRow(row)
+				if err != nil {
+					t.Errorf("unexpected error in ProcessRow(): %s", err)
+				}
+			}
+		}
+
+		err = finishPipeline(step, nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		var groupList []string
+		for _, row := range collector.rows {
+			groupList = append(groupList, row.GetString("day")+":"+row.GetString("lowest")+":"+row.GetString("highest"))
+		}
+
+		want := "2026-01-09:18:90 2026-01-10:20:100"
+		got := strings.Join(groupList, " ")
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of integer sum overflow", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		step, err := NewAggregateStep(AggregateConfig{GroupBy: []string{"branch"}, FieldList: []AggregateField{
+			{Name: "total", Function: "sum", Field: "amount"},
+		}}, inputFieldList)
+		if err != nil {
+			t.Errorf("unexpected error in NewAggregateStep(): %s", err)
+			return
+		}
+		step.SetNextStep(collector)
+
+		for _, amount := range []string{"9223372036854775807", "1", "1"} {
+			_, err = step.ProcessRow(newInputTestRow(map[string]string{"branch": "1", "amount": amount}, inputFieldList))
+			if err != nil {
+				t.Errorf("unexpected error in ProcessRow(): %s", err)
+			}
+		}
+
+		err = finishPipeline(step, nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		want := "9223372036854775809"
+		got := ""
+		for _, row := range collector.rows {
+			got += row.GetString("total")
+		}
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of decimal sums", func(t *testing.T) {
+
+		decimalFieldList := []DataField{
+			{Name: "branch", Type: "integer"},
+			{Name: "amount", Type: "string"},
+		}
+
+		collector := &rowCollector{}
+
+		step, err := NewAggregateStep(AggregateConfig{GroupBy: []string{"branch"}, FieldList: []AggregateField{
+			{Name: "total", Function: "sum", Field: "amount"},
+			{Name: "average", Function: "avg", Field: "amount"},
+		}}, decimalFieldList)
+		if err != nil {
+			t.Errorf("unexpected error in NewAggregateStep(): %s", err)
+			return
+		}
+		step.SetNextStep(collector)
+
+		for _, amount := range []string{"0.10", "0.10", "0.10"} {
+			_, err = step.ProcessRow(newInputTestRow(map[string]string{"branch": "1", "amount": amount}, decimalFieldList))
+			if err != nil {
+				t.Errorf("unexpected error in ProcessRow(): %s", err)
+			}
+		}
+
+		err = finishPipeline(step, nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		want := "0.30:0.10"
+		got := ""
+		for _, row := range collector.rows {
+			got += row.GetString("total") + ":" + row.GetString("average")
+		}
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of interrupted job", func(t *testing.T) {
+
+		firstGroup := map[string]string{"hash": "10", "stream": "2"}
+
+		for _, test := range testScenarios[:2] {
+
+			fmt.Printf("scenario: interrupted job in %s\n", test.scenario)
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			//	the job is interrupted once the first group is sent
+			var groups []string
+			collector := &rowCopyStep{
+				process: func(row *Row) error {
+					groups = append(groups, row.GetString("branch"))
+					cancel()
+					return nil
+				},
+			}
+
+			step, err := NewAggregateStep(AggregateConfig{Mode: test.mode, GroupBy: []string{"branch"}, FieldList: fieldList}, inputFieldList)
+			if err != nil {
+				t.Errorf("unexpected error in NewAggregateStep(): %s", err)
+				continue
+			}
+			step.SetNextStep(collector)
+
+			//	the groups still kept aren't sent once the job is interrupted
+			err = startPipeline(step, &JobContext{Context: ctx})
+			for _, row := range test.input {
+				if err == nil {
+					_, err = step.ProcessRow(newInputTestRow(row, inputFieldList))
+				}
+			}
+			if err == nil {
+				err = finishPipeline(step, nil)
+			}
+
+			got := fmt.Sprintf("%v %v", groups, err)
+			want := "[" + firstGroup[test.mode] + "] Job interrupted: context canceled"
+
+			if want != got || !isFatalError(err) {
+				t.Errorf("fail in Finish(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+
+	t.Run(">>> validation of aggregate function without field", func(t *testing.T) {
+
+		got := ""
+		want := "Missing field for aggregate function sum: total"
+
+		_, err := NewAggregateStep(AggregateConfig{FieldList: []AggregateField{{Name: "total", Function: "sum"}}}, inputFieldList)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in NewAggregateStep(): expected: %s result: %s", want, got)
+		}
+	})
+}
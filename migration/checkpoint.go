@@ -0,0 +1,272 @@
+///////////////////////////////////////////////////////////////////////////////
+//	checkpoint.go  -  Oct-19-2026  -  aldebap
+//
+//	Checkpoints saved while a job runs, so a failed job can resume from the last one
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const defaultCheckpointInterval = 10000
+
+//	position of the last row an input source processed
+type InputPosition struct {
+	FileIndex  int    `json:"file_index"`
+	FileName   string `json:"file_name"`
+	LineNumber int64  `json:"line_number"`
+	Offset     int64  `json:"offset"`
+	FileRows   int64  `json:"file_rows"`
+	Rows       int64  `json:"rows"`
+}
+
+//	state of a job saved in the checkpoint file: the input position and the size of the files written up to it
+type CheckpointState struct {
+	Job          string        `json:"job"`
+	Completed    bool          `json:"completed"`
+	Time         string        `json:"time"`
+	Input        InputPosition `json:"input"`
+	OutputSize   int64         `json:"output_size"`
+	RejectSize   int64         `json:"reject_size"`
+	RowsRejected int64         `json:"rows_rejected"`
+}
+
+//	attributes for a job's checkpoints
+type jobCheckpoint struct {
+	FileName string
+	Interval int64
+	JobName  string
+
+	state      *CheckpointState
+	pipeline   DataPipelineStep
+	output     DataOutputSinkCheckpoint
+	rejects    *rejectFile
+	rowsToSave int64
+}
+
+//	NewJobCheckpoint create a new jobCheckpoint
+func NewJobCheckpoint(config JobCheckpoint, jobName string) *jobCheckpoint {
+
+	checkpoint := &jobCheckpoint{
+		FileName: config.FileName,
+		Interval: config.Interval,
+		JobName:  jobName,
+	}
+
+	//	default values
+	if checkpoint.Interval == 0 {
+		checkpoint.Interval = defaultCheckpointInterval
+	}
+
+	return checkpoint
+}
+
+//	ValidateFormat validate the checkpoint configuration
+func (c *jobCheckpoint) ValidateFormat() error {
+
+	if len(c.FileName) == 0 {
+		return errors.New("Missing checkpoint file name")
+	}
+
+	if c.Interval < 0 {
+		return errors.New("Invalid checkpoint interval: " + strconv.FormatInt(c.Interval, 10))
+	}
+
+	return nil
+}
+
+//	Load read the state saved by the last run of the job; with no checkpoint file, the job starts from the beginning
+func (c *jobCheckpoint) Load() error {
+
+	data, err := os.ReadFile(c.FileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return errors.New("fail reading checkpoint file: " + err.Error())
+	}
+
+	state := &CheckpointState{}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return errors.New("fail reading checkpoint file: " + err.Error())
+	}
+
+	if state.Job != c.JobName {
+		return errors.New("Checkpoint file belongs to another job: " + state.Job)
+	}
+
+	c.state = state
+
+	return nil
+}
+
+//	Reset remove the state saved by a previous run, as the job starts from the beginning
+func (c *jobCheckpoint) Reset() error {
+
+	err := os.Remove(c.FileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.New("fail removing checkpoint file: " + err.Error())
+	}
+
+	return nil
+}
+
+//	State get the state the job resumes from, or nil when the job starts from the beginning
+func (c *jobCheckpoint) State() *CheckpointState {
+	return c.state
+}
+
+//	Completed check if the job finished in the run that saved the checkpoint
+func (c *jobCheckpoint) Completed() bool {
+	return c.state != nil && c.state.Completed
+}
+
+//	Attach check the job's pipeline can be checkpointed: its steps must not keep rows between calls,
+//	and its output and reject files must be able to resume
+func (c *jobCheckpoint) Attach(firstStep DataPipelineStep, output DataOutputSink, rejects *rejectFile) error {
+
+	c.pipeline = firstStep
+	c.output = nil
+	c.rejects = rejects
+
+	for step := firstStep; step != nil; step = step.GetNextStep() {
+		if step == output {
+			break
+		}
+
+		switch step.(type) {
+		case *traceData, *parallelStep:
+			continue
+		}
+
+		if !isConcurrentSafe(step) {
+			return errors.New("Checkpoints not supported with pipeline step: " + fmt.Sprintf("%T", step))
+		}
+	}
+
+	if output != nil {
+		checkpointOutput, ok := output.(DataOutputSinkCheckpoint)
+		if !ok {
+			return errors.New("Checkpoints not supported with output: " + fmt.Sprintf("%T", output))
+		}
+
+		err := checkpointOutput.ValidateCheckpoint()
+		if err != nil {
+			return err
+		}
+		c.output = checkpointOutput
+	}
+
+	if len(rejects.FileName) > 0 {
+		return validateResumableOutput(rejects.FileName, rejects.Compression)
+	}
+
+	return nil
+}
+
+//	ResumePosition get the input position the job resumes from, or nil when the job starts from the beginning
+func (c *jobCheckpoint) ResumePosition() *InputPosition {
+
+	if c.state == nil {
+		return nil
+	}
+
+	return &c.state.Input
+}
+
+//	Checkpoint count the rows processed by the input source, saving a checkpoint every interval rows
+func (c *jobCheckpoint) Checkpoint(position InputPosition) error {
+
+	c.rowsToSave++
+	if c.rowsToSave < c.Interval {
+		return nil
+	}
+
+	return c.save(position, false)
+}
+
+//	Complete save the job as completed, so it isn't performed again when resuming
+func (c *jobCheckpoint) Complete() error {
+
+	position := InputPosition{}
+	if c.state != nil {
+		position = c.state.Input
+	}
+
+	return c.save(position, true)
+}
+
+//	save write the state of the job at the input position; the rows still in the pipeline are written first, so
+//	the output and reject files have every row up to the position
+func (c *jobCheckpoint) save(position InputPosition, completed bool) error {
+
+	//	rows after a fatal error were discarded, so the checkpoint can't go past it
+	err := drainPipeline(c.pipeline)
+	if err != nil {
+		return err
+	}
+
+	state := &CheckpointState{
+		Job:       c.JobName,
+		Completed: completed,
+		Time:      time.Now().Format(time.RFC3339),
+		Input:     position,
+	}
+
+	if c.output != nil && !completed {
+		state.OutputSize, err = c.output.Checkpoint()
+		if err != nil {
+			return errors.New("fail saving checkpoint: " + err.Error())
+		}
+	}
+
+	if c.rejects != nil && !completed {
+		state.RejectSize, state.RowsRejected, err = c.rejects.Checkpoint()
+		if err != nil {
+			return errors.New("fail saving checkpoint: " + err.Error())
+		}
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.New("fail saving checkpoint: " + err.Error())
+	}
+
+	//	the state is written to a temporary file and renamed, so a failure never leaves a partial checkpoint
+	tempFile, err := os.CreateTemp(filepath.Dir(c.FileName), filepath.Base(c.FileName)+".*")
+	if err != nil {
+		return errors.New("fail saving checkpoint: " + err.Error())
+	}
+
+	_, err = tempFile.Write(append(data, '\n'))
+	if err == nil {
+		err = tempFile.Sync()
+	}
+
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), c.FileName)
+	}
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return errors.New("fail saving checkpoint: " + err.Error())
+	}
+
+	c.state = state
+	c.rowsToSave = 0
+
+	return nil
+}
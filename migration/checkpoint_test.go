@@ -0,0 +1,298 @@
+///////////////////////////////////////////////////////////////////////////////
+//	checkpoint_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the job checkpoints
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+//	test checkpointer that keeps the positions reported by the input source
+type inputCheckpointerMock struct {
+	resume       *InputPosition
+	positionList []InputPosition
+}
+
+func (c *inputCheckpointerMock) ResumePosition() *InputPosition {
+	return c.resume
+}
+
+func (c *inputCheckpointerMock) Checkpoint(position InputPosition) error {
+
+	c.positionList = append(c.positionList, position)
+
+	return nil
+}
+
+//	Test_InputFiles_Resume test cases for resuming the input files from a checkpoint position
+func Test_InputFiles_Resume(t *testing.T) {
+
+	err := os.WriteFile("testData_1.txt", []byte("HEADER\r\n1,Ann\r\n2,Bob\r\n"), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove("testData_1.txt")
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	gzipWriter.Write([]byte("HEADER\n3,Carl\n4,Dave\n5,Eve"))
+	gzipWriter.Close()
+
+	err = os.WriteFile("testData_2.txt.gz", compressed.Bytes(), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove("testData_2.txt.gz")
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "string"},
+	}
+
+	importRows := func(checkpointer InputCheckpointer) (string, int64) {
+
+		collector := &rowCollector{}
+
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       "testData_1.txt",
+			FileNameList:   []string{"testData_2.txt.gz"},
+			FieldSeparator: ",",
+			Header:         true,
+			FieldList:      fieldList,
+		})
+		testDataSource.SetCheckpointer(checkpointer)
+
+		rowsProcessed, err := testDataSource.ImportData(context.Background(), collector)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		var rowList []string
+		for _, row := range collector.rows {
+			rowList = append(rowList, fmt.Sprintf("%s:%d:%s", row.Source, row.LineNumber, row.GetString("name")))
+		}
+
+		return fmt.Sprint(rowList), rowsProcessed
+	}
+
+	t.Run(">>> validation of reported positions", func(t *testing.T) {
+
+		checkpointer := &inputCheckpointerMock{}
+
+		importRows(checkpointer)
+
+		want := "[{0 testData_1.txt 2 15 1 1} {0 testData_1.txt 3 22 2 2} {1 testData_2.txt.gz 2 14 1 3} " +
+			"{1 testData_2.txt.gz 3 21 2 4} {1 testData_2.txt.gz 4 26 3 5}]"
+		got := fmt.Sprint(checkpointer.positionList)
+
+		if want != got {
+			t.Errorf("fail in ImportData(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of import resumed from a position", func(t *testing.T) {
+
+		//	resume after the first row of each file
+		for _, position := range []InputPosition{
+			{FileIndex: 0, FileName: "testData_1.txt", LineNumber: 2, Offset: 15, FileRows: 1, Rows: 1},
+			{FileIndex: 1, FileName: "testData_2.txt.gz", LineNumber: 2, Offset: 14, FileRows: 1, Rows: 3},
+		} {
+			resume := position
+			got, rowsProcessed := importRows(&inputCheckpointerMock{resume: &resume})
+
+			want := "[testData_1.txt:3:Bob testData_2.txt.gz:2:Carl testData_2.txt.gz:3:Dave testData_2.txt.gz:4:Eve]"
+			if position.FileIndex == 1 {
+				want = "[testData_2.txt.gz:3:Dave testData_2.txt.gz:4:Eve]"
+			}
+
+			if want != got || rowsProcessed != 5 {
+				t.Errorf("fail in ImportData(): expected: %s result: %s (%d rows)", want, got, rowsProcessed)
+			}
+		}
+	})
+
+	t.Run(">>> validation of position from another file", func(t *testing.T) {
+
+		checkpointer := &inputCheckpointerMock{resume: &InputPosition{FileIndex: 1, FileName: "other.txt", LineNumber: 2, Offset: 14}}
+
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       "testData_1.txt",
+			FileNameList:   []string{"testData_2.txt.gz"},
+			FieldSeparator: ",",
+			Header:         true,
+			FieldList:      fieldList,
+		})
+		testDataSource.SetCheckpointer(checkpointer)
+
+		got := ""
+		want := "Checkpoint doesn't match input file: expected other.txt, found testData_2.txt.gz"
+
+		_, err := testDataSource.ImportData(context.Background(), &rowCollector{})
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in ImportData(): expected: %s result: %s", want, got)
+		}
+	})
+}
+
+//	Test_Checkpoint_Resume test cases for resuming a failed job from its last checkpoint
+func Test_Checkpoint_Resume(t *testing.T) {
+
+	const testFileName = "testData.txt"
+	const testScriptName = "testScript.sql"
+	const testRejectName = "testReject.csv"
+	const testStateName = "testState.json"
+
+	const config = `
+jobs:
+  - name: test
+    workers: 2
+    input:
+      type: CSVFile
+      file_name: testData.txt
+      field_separator: ","
+      fields:
+        - name: id
+          type: integer
+        - name: name
+          type: string
+    steps:
+      - type: filter
+        condition: id <> 5
+    output:
+      type: SQLScript
+      file_name: testScript.sql
+      dialect: postgres
+      table_name: test
+    reject:
+      file_name: testReject.csv
+      max_errors: 0
+    checkpoint:
+      file_name: testState.json
+      interval: 2
+`
+	var input strings.Builder
+	for i := 1; i <= 10; i++ {
+		fmt.Fprintf(&input, "%d,NAME#%d\n", i, i)
+	}
+	goodInput := input.String()
+	badInput := strings.Replace(goodInput, "7,NAME#7", "X,NAME#7", 1)
+
+	defer os.Remove(testFileName)
+	defer os.Remove(testScriptName)
+	defer os.Remove(testRejectName)
+	defer os.Remove(testStateName)
+
+	dmig, err := LoadConfigFile(bufio.NewReader(strings.NewReader(config)))
+	if err != nil {
+		t.Errorf("unexpected error in LoadConfigFile(): %s", err)
+		return
+	}
+
+	readFile := func(fileName string) string {
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Errorf("unexpected error reading %s: %s", fileName, err)
+		}
+		return string(data)
+	}
+
+	t.Run(">>> validation of job resumed from checkpoint", func(t *testing.T) {
+
+		//	the job fails at line 7, after the checkpoint of line 6
+		os.WriteFile(testFileName, []byte(badInput), 0644)
+
+		err := dmig.performJob(context.Background(), &dmig.JobList[0], &bytes.Buffer{})
+		if err == nil || !strings.HasPrefix(err.Error(), "testData.txt, line 7: Job aborted") {
+			t.Errorf("fail in performJob(): expected error at line 7, result: %v", err)
+		}
+		if state := readFile(testStateName); !strings.Contains(state, `"line_number": 6`) {
+			t.Errorf("fail saving checkpoint: unexpected state: %s", state)
+		}
+
+		//	once the input is fixed, the job resumes after line 6
+		os.WriteFile(testFileName, []byte(goodInput), 0644)
+		dmig.Resume = true
+
+		messages := &bytes.Buffer{}
+
+		err = dmig.performJob(context.Background(), &dmig.JobList[0], messages)
+		if err != nil {
+			t.Errorf("unexpected error in performJob(): %s", err)
+		}
+		if !strings.Contains(messages.String(), "Resuming from checkpoint: testData.txt, line 6") ||
+			!strings.Contains(messages.String(), "Job finished: 10 rows processed") {
+			t.Errorf("fail in performJob(): unexpected messages: %s", messages.String())
+		}
+		resumedScript := readFile(testScriptName)
+		resumedRejects := readFile(testRejectName)
+
+		//	a completed job isn't performed again
+		messages.Reset()
+
+		err = dmig.performJob(context.Background(), &dmig.JobList[0], messages)
+		if err != nil || !strings.Contains(messages.String(), "Job already finished in a previous run") {
+			t.Errorf("fail in performJob(): unexpected messages: %s (%v)", messages.String(), err)
+		}
+
+		//	the resumed job must write the same output as a job run from the beginning
+		dmig.Resume = false
+
+		err = dmig.performJob(context.Background(), &dmig.JobList[0], &bytes.Buffer{})
+		if err != nil {
+			t.Errorf("unexpected error in performJob(): %s", err)
+		}
+
+		if want := readFile(testScriptName); want != resumedScript {
+			t.Errorf("fail resuming SQL script: expected: %s result: %s", want, resumedScript)
+		}
+		if want := readFile(testRejectName); want != resumedRejects {
+			t.Errorf("fail resuming reject file: expected: %s result: %s", want, resumedRejects)
+		}
+	})
+
+	t.Run(">>> validation of steps that can't be checkpointed", func(t *testing.T) {
+
+		job := dmig.JobList[0]
+		job.Steps = nil
+		job.Workers = 0
+
+		sortConfig, err := LoadConfigFile(bufio.NewReader(strings.NewReader(`
+jobs:
+  - steps:
+      - type: sort
+        fields:
+          - name: id
+`)))
+		if err != nil {
+			t.Errorf("unexpected error in LoadConfigFile(): %s", err)
+			return
+		}
+		job.Steps = sortConfig.JobList[0].Steps
+
+		got := ""
+		want := "Checkpoints not supported with pipeline step: *migration.sortStep"
+
+		err = dmig.performJob(context.Background(), &job, &bytes.Buffer{})
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in performJob(): expected: %s result: %s", want, got)
+		}
+	})
+}
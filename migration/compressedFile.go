@@ -0,0 +1,309 @@
+///////////////////////////////////////////////////////////////////////////////
+//	compressedFile.go  -  Oct-19-2026  -  aldebap
+//
+//	Transparent compression for input and output files, including standard input and output
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//	constants for compression types
+const (
+	NO_COMPRESSION = 1
+	GZIP           = 2
+	BZIP2          = 3
+)
+
+var (
+	compression_type = map[string]uint8{
+		"none":  NO_COMPRESSION,
+		"gzip":  GZIP,
+		"bzip2": BZIP2,
+	}
+
+	compression_extension = map[string]uint8{
+		".gz":  GZIP,
+		".bz2": BZIP2,
+	}
+)
+
+//	fileCompression get the compression of a file from the compression attribute or from the file extension
+func fileCompression(fileName string, compression string) (uint8, error) {
+
+	if len(compression) > 0 {
+		compressionType, found := compression_type[compression]
+		if !found {
+			return 0, errors.New("Invalid compression: " + compression)
+		}
+
+		return compressionType, nil
+	}
+
+	compressionType, found := compression_extension[strings.ToLower(filepath.Ext(fileName))]
+	if !found {
+		return NO_COMPRESSION, nil
+	}
+
+	return compressionType, nil
+}
+
+//	validateCompression validate the compression attribute of a file
+func validateCompression(compression string) error {
+
+	if len(compression) > 0 {
+		_, found := compression_type[compression]
+		if !found {
+			return errors.New("Invalid compression: " + compression)
+		}
+	}
+
+	return nil
+}
+
+//	validateInputCompression validate the compression of an input file: every compression can be read
+func validateInputCompression(fileName string, compression string) error {
+
+	_, err := fileCompression(fileName, compression)
+
+	return err
+}
+
+//	validateOutputCompression validate the compression of an output file: the standard library only writes gzip,
+//	so bzip2 files can be read but not written
+func validateOutputCompression(fileName string, compression string) error {
+
+	compressionType, err := fileCompression(fileName, compression)
+	if err != nil {
+		return err
+	}
+
+	if compressionType != NO_COMPRESSION && compressionType != GZIP {
+		return errors.New("Unsupported compression for output files, only gzip is available: " + fileName)
+	}
+
+	return nil
+}
+
+//	file name used for standard input and output
+const STANDARD_STREAM = "-"
+
+//	writer that doesn't close the underlying stream
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (w nopWriteCloser) Close() error {
+	return nil
+}
+
+//	reader that closes both the decompressor and the underlying file
+type compressedReader struct {
+	io.Reader
+
+	decompressor io.Closer
+	file         io.Closer
+}
+
+func (r *compressedReader) Close() error {
+
+	if r.decompressor != nil {
+		r.decompressor.Close()
+	}
+
+	return r.file.Close()
+}
+
+//	openInputFile open an input file for reading, decompressing it when required
+func openInputFile(fileName string, compression string) (io.ReadCloser, error) {
+
+	err := validateInputCompression(fileName, compression)
+	if err != nil {
+		return nil, err
+	}
+
+	compressionType, _ := fileCompression(fileName, compression)
+
+	var file io.ReadCloser
+
+	if fileName == STANDARD_STREAM {
+		file = io.NopCloser(os.Stdin)
+	} else {
+		file, err = os.Open(fileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch compressionType {
+	case GZIP:
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		return &compressedReader{Reader: gzipReader, decompressor: gzipReader, file: file}, nil
+
+	case BZIP2:
+		return &compressedReader{Reader: bzip2.NewReader(file), file: file}, nil
+	}
+
+	return file, nil
+}
+
+//	writer that closes both the compressor and the underlying file
+type compressedWriter struct {
+	io.Writer
+
+	compressor io.Closer
+	file       io.Closer
+}
+
+func (w *compressedWriter) Close() error {
+
+	err := w.compressor.Close()
+
+	closeErr := w.file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+//	partialFileName get the name an output file is written to until the job succeeds: a hidden file in the same
+//	directory, so it can be renamed into place, and the tools watching the directory don't pick it up
+func partialFileName(fileName string) string {
+	return filepath.Join(filepath.Dir(fileName), "."+filepath.Base(fileName)+".partial")
+}
+
+//	createOutputFile create an output file for writing, compressing it when required; the file is written with
+//	its partial name, and commitOutputFile renames it into place
+func createOutputFile(fileName string, compression string) (io.WriteCloser, error) {
+
+	err := validateOutputCompression(fileName, compression)
+	if err != nil {
+		return nil, err
+	}
+
+	compressionType, _ := fileCompression(fileName, compression)
+
+	var file io.WriteCloser
+
+	if fileName == STANDARD_STREAM {
+		file = nopWriteCloser{Writer: os.Stdout}
+	} else {
+		file, err = os.Create(partialFileName(fileName))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if compressionType == GZIP {
+		gzipWriter := gzip.NewWriter(file)
+
+		return &compressedWriter{Writer: gzipWriter, compressor: gzipWriter, file: file}, nil
+	}
+
+	return file, nil
+}
+
+//	commitOutputFile rename a closed output file into place once the job succeeds, and write its done marker
+func commitOutputFile(fileName string, doneMarker bool) error {
+
+	if fileName == STANDARD_STREAM {
+		return nil
+	}
+
+	err := os.Rename(partialFileName(fileName), fileName)
+	if err != nil {
+		return err
+	}
+
+	//	the marker is only written after the file is in place
+	if doneMarker {
+		return os.WriteFile(fileName+".done", nil, 0644)
+	}
+
+	return nil
+}
+
+//	discardOutputFile remove the partial output file of a failed job
+func discardOutputFile(fileName string) {
+
+	if fileName != STANDARD_STREAM {
+		os.Remove(partialFileName(fileName))
+	}
+}
+
+//	validateResumableOutput check if writing an output file can resume from a checkpoint: the file must be a local,
+//	uncompressed file
+func validateResumableOutput(fileName string, compression string) error {
+
+	compressionType, err := fileCompression(fileName, compression)
+	if err != nil {
+		return err
+	}
+
+	if fileName == STANDARD_STREAM || compressionType != NO_COMPRESSION {
+		return errors.New("Checkpoints need an uncompressed output file: " + fileName)
+	}
+
+	return nil
+}
+
+//	resumeOutputFile open the partial output file written up to a checkpoint, discarding what was written after it
+func resumeOutputFile(fileName string, compression string, size int64) (io.WriteCloser, error) {
+
+	err := validateResumableOutput(fileName, compression)
+	if err != nil {
+		return nil, err
+	}
+
+	partialName := partialFileName(fileName)
+
+	//	a file the previous run renamed into place is written with its partial name again
+	_, err = os.Stat(partialName)
+	if errors.Is(err, os.ErrNotExist) {
+		err = os.Rename(fileName, partialName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(partialName, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = file.Truncate(size)
+	if err == nil {
+		_, err = file.Seek(size, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return file, nil
+}
+
+//	outputFileSize get the size of an uncompressed output file, as written so far
+func outputFileSize(file io.WriteCloser) (int64, error) {
+
+	osFile, ok := file.(*os.File)
+	if !ok {
+		return 0, errors.New("output file size not available")
+	}
+
+	return osFile.Seek(0, io.SeekCurrent)
+}
@@ -0,0 +1,190 @@
+///////////////////////////////////////////////////////////////////////////////
+//	compressedFile_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for transparent compression of input and output files, including standard input and output
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+//	Test_FileCompression test cases for compression detection
+func Test_FileCompression(t *testing.T) {
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario    string
+		fileName    string
+		compression string
+		output      uint8
+		err         string
+	}{
+		{scenario: "plain file", fileName: "data.txt", output: NO_COMPRESSION},
+		{scenario: "gzip extension", fileName: "data.txt.gz", output: GZIP},
+		{scenario: "bzip2 extension", fileName: "data.TXT.BZ2", output: BZIP2},
+		{scenario: "unknown extension", fileName: "data.txt.zst", output: NO_COMPRESSION},
+		{scenario: "explicit compression", fileName: "data.dat", compression: "gzip", output: GZIP},
+		{scenario: "explicit no compression", fileName: "data.gz", compression: "none", output: NO_COMPRESSION},
+		{scenario: "invalid compression", fileName: "data.txt", compression: "xpto", err: "Invalid compression: xpto"},
+		{scenario: "unsupported compression", fileName: "data.txt.xz", compression: "xz", err: "Invalid compression: xz"},
+	}
+
+	t.Run(">>> validation of compression detection", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			got, err := fileCompression(test.fileName, test.compression)
+			if err != nil {
+				if err.Error() != test.err {
+					t.Errorf("fail in fileCompression(): expected: %s result: %s", test.err, err)
+				}
+				continue
+			}
+
+			if test.output != got {
+				t.Errorf("fail in fileCompression(): expected: %d result: %d", test.output, got)
+			}
+		}
+	})
+}
+
+//	Test_CompressedFile_ImportData test cases for importing compressed data files
+func Test_CompressedFile_ImportData(t *testing.T) {
+
+	t.Run(">>> validation of gzip compressed data file importing", func(t *testing.T) {
+
+		const testFileName = "testData.txt.gz"
+
+		dataFile, err := createOutputFile(testFileName, "")
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(testFileName)
+
+		io.WriteString(dataFile, "1,LINE#1\n2,LINE#2\n")
+		err = dataFile.Close()
+		if err == nil {
+			err = commitOutputFile(testFileName, false)
+		}
+		if err != nil {
+			t.Errorf("unexpected error closing test file: %s", err)
+		}
+
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       testFileName,
+			FieldSeparator: ",",
+			FieldList: []DataField{
+				{Name: "test_1", Type: "string"},
+				{Name: "test_2", Type: "string"},
+			},
+		})
+
+		//	import data
+		got := int64(0)
+		want := int64(2)
+
+		got, err = testDataSource.ImportData(context.Background(), nil)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		if want != got {
+			t.Errorf("fail in ImportData(): expected: %d result: %d", want, got)
+		}
+	})
+
+	t.Run(">>> validation of unsupported compression", func(t *testing.T) {
+
+		testDataSource := NewFixedPositionInputFile(JobInput{FileName: "testData.txt.zst", Compression: "zstd"})
+
+		got := ""
+		want := "fail opening data file: Invalid compression: zstd"
+
+		_, err := testDataSource.ImportData(context.Background(), nil)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in ImportData(): expected: %s result: %s", want, got)
+		}
+	})
+}
+
+//	Test_StandardStreams test cases for using stdin and stdout as job endpoints
+func Test_StandardStreams(t *testing.T) {
+
+	t.Run(">>> validation of stdin input and stdout output", func(t *testing.T) {
+
+		const testFileName = "testData.txt"
+		const testScriptName = "testScript.sql"
+
+		err := os.WriteFile(testFileName, []byte("1,LINE#1\n2,LINE#2\n"), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(testFileName)
+		defer os.Remove(testScriptName)
+
+		//	redirect standard input and output to test files
+		stdin, stdout := os.Stdin, os.Stdout
+		defer func() {
+			os.Stdin, os.Stdout = stdin, stdout
+		}()
+
+		os.Stdin, err = os.Open(testFileName)
+		if err != nil {
+			t.Errorf("unexpected error opening test file: %s", err)
+		}
+		defer os.Stdin.Close()
+
+		os.Stdout, err = os.Create(testScriptName)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+
+		fieldList := []DataField{
+			{Name: "test_1", Type: "integer"},
+			{Name: "test_2", Type: "string"},
+		}
+
+		testDataSource := NewCSVInputFile(JobInput{FileName: STANDARD_STREAM, FieldSeparator: ",", FieldList: fieldList})
+		testOutput := NewSQLScriptOutput(JobOutput{FileName: STANDARD_STREAM, Dialect: "sqlite", TableName: "test", FieldList: fieldList})
+
+		err = testOutput.Start(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Start(): %s", err)
+		}
+
+		_, err = testDataSource.ImportData(context.Background(), testOutput)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		err = testOutput.Finish(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+		os.Stdout.Close()
+
+		script, err := os.ReadFile(testScriptName)
+		if err != nil {
+			t.Errorf("unexpected error reading SQL script: %s", err)
+		}
+
+		got := string(script)
+		want := "INSERT INTO test (test_1, test_2) VALUES (1, 'LINE#1');\nINSERT INTO test (test_1, test_2) VALUES (2, 'LINE#2');\n"
+
+		if want != got {
+			t.Errorf("fail writing to stdout: expected: %s result: %s", want, got)
+		}
+	})
+}
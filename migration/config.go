@@ -18,6 +18,23 @@ type DataField struct {
 	Type          string `yaml:"type"`
 	StartPosition int16  `yaml:"start"`
 	EndPosition   int16  `yaml:"end"`
+	Column        string `yaml:"column"`
+
+	//	layout of date and timestamp values, like YYYY-MM-DD HH:MI:SS
+	Format string `yaml:"format"`
+
+	//	input values read as null, compared without padding spaces
+	NullValues []string `yaml:"null_values"`
+
+	//	constraints checked for every row
+	Required      bool     `yaml:"required"`
+	Min           string   `yaml:"min"`
+	Max           string   `yaml:"max"`
+	MinLength     int      `yaml:"min_length"`
+	MaxLength     int      `yaml:"max_length"`
+	Pattern       string   `yaml:"pattern"`
+	AllowedValues []string `yaml:"allowed_values"`
+	Unique        bool     `yaml:"unique"`
 }
 
 //	attributes for a migration job
@@ -25,26 +42,108 @@ type JobInput struct {
 	Description    string      `yaml:"description"`
 	Type           string      `yaml:"type"`
 	FileName       string      `yaml:"file_name"`
+	FileNameList   []string    `yaml:"file_names"`
+	Compression    string      `yaml:"compression"`
 	FieldSeparator string      `yaml:"field_separator"`
 	Header         bool        `yaml:"header"`
 	Trailer        bool        `yaml:"trailer"`
 	FieldList      []DataField `yaml:"fields"`
 }
 
+//	attributes for a migration job output: without a null_value, nulls are written as the sink's own null;
+//	with done_marker, an empty <file_name>.done is written once the output file is complete
+type JobOutput struct {
+	Description string      `yaml:"description"`
+	Type        string      `yaml:"type"`
+	FileName    string      `yaml:"file_name"`
+	Compression string      `yaml:"compression"`
+	Driver      string      `yaml:"driver"`
+	DataSource  string      `yaml:"data_source"`
+	Dialect     string      `yaml:"dialect"`
+	TableName   string      `yaml:"table_name"`
+	Mode        string      `yaml:"mode"`
+	KeyFields   []string    `yaml:"key_fields"`
+	BatchSize   int         `yaml:"batch_size"`
+	Transaction string      `yaml:"transaction"`
+	NullValue   *string     `yaml:"null_value"`
+	DoneMarker  bool        `yaml:"done_marker"`
+	FieldList   []DataField `yaml:"fields"`
+}
+
+//	attributes for a migration job's rejected rows: max_errors and max_error_percent abort the job when exceeded
+type JobReject struct {
+	FileName        string   `yaml:"file_name"`
+	Compression     string   `yaml:"compression"`
+	MaxErrors       *int64   `yaml:"max_errors"`
+	MaxErrorPercent *float64 `yaml:"max_error_percent"`
+}
+
+//	attributes for a migration job's checkpoints: the state file and the number of rows between checkpoints
+type JobCheckpoint struct {
+	FileName string `yaml:"file_name"`
+	Interval int64  `yaml:"interval"`
+}
+
+//	attributes for a migration job pipeline step: the step type and its type specific parameters
+type JobStep struct {
+	Type string `yaml:"type"`
+
+	config yaml.Node
+}
+
+//	UnmarshalYAML keep the step's yaml node to decode its parameters when the step is created
+func (s *JobStep) UnmarshalYAML(value *yaml.Node) error {
+
+	var stepType struct {
+		Type string `yaml:"type"`
+	}
+
+	err := value.Decode(&stepType)
+	if err != nil {
+		return err
+	}
+
+	s.Type = stepType.Type
+	s.config = *value
+
+	return nil
+}
+
+//	Decode decode the step's parameters into a step specific configuration
+func (s *JobStep) Decode(config interface{}) error {
+
+	if s.config.Kind == 0 {
+		return nil
+	}
+
+	return s.config.Decode(config)
+}
+
 //	attributes for a migration job
 type MigrationJob struct {
-	Name        string   `yaml:"name"`
-	Description string   `yaml:"description"`
-	Input       JobInput `yaml:"input"`
-	Trace       bool     `yaml:"trace"`
+	Name           string        `yaml:"name"`
+	Description    string        `yaml:"description"`
+	DependsOn      []string      `yaml:"depends_on"`
+	Input          JobInput      `yaml:"input"`
+	SecondaryInput JobInput      `yaml:"secondary_input"`
+	Steps          []JobStep     `yaml:"steps"`
+	Output         JobOutput     `yaml:"output"`
+	Reject         JobReject     `yaml:"reject"`
+	Checkpoint     JobCheckpoint `yaml:"checkpoint"`
+	Trace          bool          `yaml:"trace"`
+	Workers        int           `yaml:"workers"`
 }
 
 //	attributes used to configure a migration
 type DataMigration struct {
-	Description string         `yaml:"description"`
-	Author      string         `yaml:"author"`
-	Date        string         `yaml:"date"`
-	JobList     []MigrationJob `yaml:"jobs"`
+	Description     string         `yaml:"description"`
+	Author          string         `yaml:"author"`
+	Date            string         `yaml:"date"`
+	MaxParallelJobs int            `yaml:"max_parallel_jobs"`
+	JobList         []MigrationJob `yaml:"jobs"`
+
+	//	when set, the jobs resume from their last checkpoint
+	Resume bool `yaml:"-"`
 }
 
 //	LoadConfigFile load a migration config file return a DataMigration
@@ -7,18 +7,24 @@
 package migration
 
 import (
-	"bufio"
+	"context"
 	"errors"
-	"os"
 	"strings"
 )
 
 //	attributes for a CSV Input file
 type csvInputFile struct {
 	FileName       string
+	FileNameList   []string
+	Compression    string
 	FieldSeparator string
 	Header         bool
+	Trailer        bool
 	FieldList      []DataField
+
+	processedFiles []ProcessedFile
+	rejecter       RowRejecter
+	checkpointer   InputCheckpointer
 }
 
 //	NewCSVInputFile create a new csvInputFile
@@ -26,8 +32,11 @@ func NewCSVInputFile(config JobInput) DataInputSource {
 
 	return &csvInputFile{
 		FileName:       config.FileName,
+		FileNameList:   config.FileNameList,
+		Compression:    config.Compression,
 		FieldSeparator: config.FieldSeparator,
 		Header:         config.Header,
+		Trailer:        config.Trailer,
 		FieldList:      config.FieldList,
 	}
 }
@@ -65,56 +74,80 @@ func (f *csvInputFile) ValidateFormat() error {
 		}
 	}
 
-	return nil
+	return f.inputFileSet().validate()
 }
 
-//	ImportData open fixed position file and import its data
-func (f *csvInputFile) ImportData(nextStep DataPipelineStep) (rowsProcessed int64, err error) {
-
-	//	 open fixed position file
-	dataFile, err := os.Open(f.FileName)
-	if err != nil {
-		return 0, errors.New("fail opening data file: " + err.Error())
-	}
-	defer dataFile.Close()
-
-	//	read config file line by line
-	var dataRow []byte
-	var rowValue map[string]string
+//	ImportData open the data files (or each archive member they point to) and import their data
+func (f *csvInputFile) ImportData(ctx context.Context, nextStep DataPipelineStep) (rowsProcessed int64, err error) {
 
-	rowValue = make(map[string]string)
+	rowValue := NewRow()
 
-	rowsProcessed = 0
-	dataFileReader := bufio.NewReader(dataFile)
+	input := f.inputFileSet()
 
-	for {
-		dataRow, _, err = dataFileReader.ReadLine()
-		if err != nil {
-			break
-		}
-		//	if file have a reader, ignores it
-		if rowsProcessed == 0 && f.Header {
-			continue
-		}
+	rowsProcessed, err = input.readLines(ctx, func(source string, lineNumber int64, dataRow []byte) error {
 
 		//	extract fields from input line
-		values := strings.Split(string(dataRow), string(f.FieldSeparator))
+		line := string(dataRow)
+		values := strings.Split(line, string(f.FieldSeparator))
+
+		rowValue.Reset()
 
 		for i, field := range f.FieldList {
-			if i < len(values) {
-				rowValue[field.Name] = values[i]
-			} else {
-				rowValue[field.Name] = ""
+			//	fields missing from short lines are null
+			if i >= len(values) {
+				rowValue.Set(field.Name, nil)
+				continue
+			}
+
+			err := setRowValue(rowValue, field, values[i])
+			if err != nil {
+				return rowError(f.rejecter, source, lineNumber, dataRow, err)
 			}
 		}
 
+		rowValue.Source = source
+		rowValue.LineNumber = lineNumber
+		rowValue.Record = line
+
 		//	if available, invoke the next step in the pipeline
 		if nextStep != nil {
-			nextStep.ProcessRow(rowValue)
+			_, err := nextStep.ProcessRow(rowValue)
+			if err != nil {
+				return rowError(f.rejecter, source, lineNumber, dataRow, err)
+			}
 		}
 
-		rowsProcessed++
-	}
+		return nil
+	})
+	f.processedFiles = input.processedFiles
+
+	return rowsProcessed, err
+}
 
-	return rowsProcessed, nil
+//	SetRowRejecter set the rejecter for the rows the pipeline fails to process
+func (f *csvInputFile) SetRowRejecter(rejecter RowRejecter) {
+	f.rejecter = rejecter
+}
+
+//	SetCheckpointer set the checkpointer that saves the position of the processed rows
+func (f *csvInputFile) SetCheckpointer(checkpointer InputCheckpointer) {
+	f.checkpointer = checkpointer
+}
+
+//	ProcessedFiles get the list of files processed by the last import, with their row counts
+func (f *csvInputFile) ProcessedFiles() []ProcessedFile {
+	return f.processedFiles
+}
+
+//	inputFileSet get the set of input files
+func (f *csvInputFile) inputFileSet() *inputFiles {
+
+	return &inputFiles{
+		FileName:     f.FileName,
+		FileNameList: f.FileNameList,
+		Compression:  f.Compression,
+		Header:       f.Header,
+		Trailer:      f.Trailer,
+		checkpointer: f.checkpointer,
+	}
 }
@@ -7,6 +7,7 @@
 package migration
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -83,7 +84,7 @@ func Test_CSVFile_ImportData(t *testing.T) {
 		got := ""
 		want := "fail opening data file: open xpto.txt: no such file or directory"
 
-		_, err := testDataSource.ImportData(nil)
+		_, err := testDataSource.ImportData(context.Background(), nil)
 		if err != nil {
 			got = err.Error()
 		}
@@ -121,7 +122,7 @@ func Test_CSVFile_ImportData(t *testing.T) {
 		got := int64(0)
 		want := int64(0)
 
-		got, err = testDataSource.ImportData(nil)
+		got, err = testDataSource.ImportData(context.Background(), nil)
 		if err != nil {
 			t.Errorf("unexpected error in ImportData(): %s", err)
 		}
@@ -159,7 +160,7 @@ func Test_CSVFile_ImportData(t *testing.T) {
 		got := int64(0)
 		want := int64(2)
 
-		got, err = testDataSource.ImportData(nil)
+		got, err = testDataSource.ImportData(context.Background(), nil)
 		if err != nil {
 			t.Errorf("unexpected error in ImportData(): %s", err)
 		}
@@ -6,7 +6,21 @@
 
 package migration
 
+import (
+	"context"
+)
+
 type DataInputSource interface {
 	ValidateFormat() error
-	ImportData(nextStep DataPipelineStep) (rowsProcessed int64, err error)
+	SetRowRejecter(rejecter RowRejecter)
+	SetCheckpointer(checkpointer InputCheckpointer)
+	ImportData(ctx context.Context, nextStep DataPipelineStep) (rowsProcessed int64, err error)
+	ProcessedFiles() []ProcessedFile
+}
+
+//	input sources report the position of each processed row to an InputCheckpointer, and start reading from the
+//	position it gives them
+type InputCheckpointer interface {
+	ResumePosition() *InputPosition
+	Checkpoint(position InputPosition) error
 }
@@ -0,0 +1,20 @@
+///////////////////////////////////////////////////////////////////////////////
+//	dataOutputSink.go  -  Oct-19-2026  -  aldebap
+//
+//	Interface of a data output sink
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+type DataOutputSink interface {
+	DataPipelineStep
+	DataPipelineStepLifecycle
+
+	ValidateFormat() error
+}
+
+//	output sinks that can save the rows written so far, so a job can resume writing after them
+type DataOutputSinkCheckpoint interface {
+	ValidateCheckpoint() error
+	Checkpoint() (outputSize int64, err error)
+}
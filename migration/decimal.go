@@ -0,0 +1,306 @@
+///////////////////////////////////////////////////////////////////////////////
+//	decimal.go  -  Oct-19-2026  -  aldebap
+//
+//	Exact decimal numbers for the values carried by the rows
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//	digits after the decimal point kept by a division that isn't exact
+const decimalDivisionScale = 10
+
+//	exact decimal number: an integer and the number of its digits after the decimal point, so 0.10 is 10 with
+//	scale 2; the values are immutable, operations return a new Decimal
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+var bigTen = big.NewInt(10)
+
+//	ParseDecimal parse a decimal number like -123.45; exponents aren't accepted
+func ParseDecimal(value string) (Decimal, error) {
+
+	digits := strings.TrimSpace(value)
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign = digits[:1]
+		digits = digits[1:]
+	}
+
+	var scale int32
+
+	i := strings.Index(digits, ".")
+	if i >= 0 {
+		scale = int32(len(digits) - i - 1)
+		digits = digits[:i] + digits[i+1:]
+	}
+
+	if len(digits) == 0 || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, errors.New("Invalid decimal number: " + value)
+	}
+
+	unscaled, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Decimal{}, errors.New("Invalid decimal number: " + value)
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+//	NewDecimal create a Decimal for an integer number
+func NewDecimal(value int64) Decimal {
+	return Decimal{unscaled: big.NewInt(value)}
+}
+
+//	decimalFromFloat create the Decimal with the shortest digits that represent a binary floating point number
+func decimalFromFloat(value float64) Decimal {
+
+	decimal, err := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+
+	return decimal
+}
+
+//	integer get the unscaled integer; the zero Decimal is zero
+func (d Decimal) integer() *big.Int {
+
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return d.unscaled
+}
+
+//	rescale get the same number with more digits after the decimal point
+func (d Decimal) rescale(scale int32) Decimal {
+
+	if scale <= d.scale {
+		return d
+	}
+
+	factor := new(big.Int).Exp(bigTen, big.NewInt(int64(scale-d.scale)), nil)
+
+	return Decimal{unscaled: new(big.Int).Mul(d.integer(), factor), scale: scale}
+}
+
+//	align get both numbers with the same scale
+func (d Decimal) align(other Decimal) (Decimal, Decimal) {
+
+	if d.scale < other.scale {
+		return d.rescale(other.scale), other
+	}
+
+	return d, other.rescale(d.scale)
+}
+
+//	String format the number with all the digits of its scale
+func (d Decimal) String() string {
+
+	digits := new(big.Int).Abs(d.integer()).String()
+
+	sign := ""
+	if d.integer().Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+}
+
+//	Sign get -1, 0 or 1 for negative numbers, zero and positive numbers
+func (d Decimal) Sign() int {
+	return d.integer().Sign()
+}
+
+//	Cmp compare two numbers, returning -1, 0 or 1
+func (d Decimal) Cmp(other Decimal) int {
+
+	d, other = d.align(other)
+
+	return d.integer().Cmp(other.integer())
+}
+
+//	Add add two numbers
+func (d Decimal) Add(other Decimal) Decimal {
+
+	d, other = d.align(other)
+
+	return Decimal{unscaled: new(big.Int).Add(d.integer(), other.integer()), scale: d.scale}
+}
+
+//	Sub subtract a number
+func (d Decimal) Sub(other Decimal) Decimal {
+
+	d, other = d.align(other)
+
+	return Decimal{unscaled: new(big.Int).Sub(d.integer(), other.integer()), scale: d.scale}
+}
+
+//	Mul multiply two numbers; the result has the digits of both scales
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.integer(), other.integer()), scale: d.scale + other.scale}
+}
+
+//	Neg get the number with the opposite sign
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.integer()), scale: d.scale}
+}
+
+//	Abs get the absolute value of the number
+func (d Decimal) Abs() Decimal {
+	return Decimal{unscaled: new(big.Int).Abs(d.integer()), scale: d.scale}
+}
+
+//	Quo divide by a non zero number; a division that isn't exact is rounded to decimalDivisionScale digits, and
+//	trailing zeros are removed down to the largest scale of the operands
+func (d Decimal) Quo(other Decimal) Decimal {
+
+	minScale := d.scale
+	if other.scale > minScale {
+		minScale = other.scale
+	}
+
+	//	the dividend is scaled so the quotient gets the division scale digits
+	scale := minScale + decimalDivisionScale
+	dividend := d.rescale(scale + other.scale)
+
+	quotient := Decimal{unscaled: roundQuo(dividend.integer(), other.integer()), scale: scale}
+
+	return quotient.trim(minScale)
+}
+
+//	Round round the number to a number of digits after the decimal point, half away from zero
+func (d Decimal) Round(digits int32) Decimal {
+
+	if digits >= d.scale {
+		return d
+	}
+
+	factor := new(big.Int).Exp(bigTen, big.NewInt(int64(d.scale-digits)), nil)
+	rounded := Decimal{unscaled: roundQuo(d.integer(), factor), scale: digits}
+
+	//	rounding to tens, hundreds, ... still results an integer
+	if digits < 0 {
+		return rounded.rescale(0)
+	}
+
+	return rounded
+}
+
+//	Int64 get the integer part of the number, and if it fits in an int64
+func (d Decimal) Int64() (int64, bool) {
+
+	integer := d.integer()
+	if d.scale > 0 {
+		factor := new(big.Int).Exp(bigTen, big.NewInt(int64(d.scale)), nil)
+		integer = new(big.Int).Quo(integer, factor)
+	}
+
+	return integer.Int64(), integer.IsInt64()
+}
+
+//	trim remove the trailing zeros after the decimal point, keeping at least minScale digits
+func (d Decimal) trim(minScale int32) Decimal {
+
+	integer := new(big.Int).Set(d.integer())
+	scale := d.scale
+
+	remainder := new(big.Int)
+	for scale > minScale {
+		quotient, _ := new(big.Int).QuoRem(integer, bigTen, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		integer = quotient
+		scale--
+	}
+
+	return Decimal{unscaled: integer, scale: scale}
+}
+
+//	roundQuo divide two integers, rounding half away from zero
+func roundQuo(dividend *big.Int, divisor *big.Int) *big.Int {
+
+	quotient, remainder := new(big.Int).QuoRem(dividend, divisor, new(big.Int))
+
+	//	round up when twice the remainder is at least the divisor
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+
+	if twice.Cmp(new(big.Int).Abs(divisor)) >= 0 {
+		if dividend.Sign()*divisor.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient
+}
+
+//	addInt64 add two integers, and check the sum fits in an int64
+func addInt64(a int64, b int64) (int64, bool) {
+
+	sum := a + b
+
+	return sum, (b >= 0) == (sum >= a)
+}
+
+//	subInt64 subtract two integers, and check the difference fits in an int64
+func subInt64(a int64, b int64) (int64, bool) {
+
+	difference := a - b
+
+	return difference, (b >= 0) == (difference <= a)
+}
+
+//	mulInt64 multiply two integers, and check the product fits in an int64
+func mulInt64(a int64, b int64) (int64, bool) {
+
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return product, false
+	}
+
+	return product, true
+}
+
+//	GobEncode encode the number as its text, so rows with decimals can be written to temporary files
+func (d Decimal) GobEncode() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//	GobDecode decode a number written by GobEncode
+func (d *Decimal) GobDecode(data []byte) error {
+
+	decimal, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = decimal
+
+	return nil
+}
@@ -0,0 +1,73 @@
+///////////////////////////////////////////////////////////////////////////////
+//	decimal_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the exact decimal numbers
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"testing"
+)
+
+//	decimalValue parse a decimal number for the test cases
+func decimalValue(value string) Decimal {
+
+	decimal, err := ParseDecimal(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return decimal
+}
+
+//	Test_Decimal_Arithmetic test cases for the decimal operations
+func Test_Decimal_Arithmetic(t *testing.T) {
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		result   Decimal
+		output   string
+	}{
+		{scenario: "parse", result: decimalValue(" -0.05 "), output: "-0.05"},
+		{scenario: "sum", result: decimalValue("0.10").Add(decimalValue("0.10")).Add(decimalValue("0.10")), output: "0.30"},
+		{scenario: "subtraction", result: decimalValue("1").Sub(decimalValue("0.9")), output: "0.1"},
+		{scenario: "multiplication", result: decimalValue("1.5").Mul(decimalValue("-0.2")), output: "-0.30"},
+		{scenario: "exact division", result: decimalValue("1.50").Quo(decimalValue("4")), output: "0.375"},
+		{scenario: "inexact division", result: decimalValue("-2").Quo(decimalValue("3")), output: "-0.6666666667"},
+		{scenario: "round half up", result: decimalValue("2.345").Round(2), output: "2.35"},
+		{scenario: "round negative", result: decimalValue("-2.5").Round(0), output: "-3"},
+		{scenario: "round to hundreds", result: decimalValue("1250").Round(-2), output: "1300"},
+		{scenario: "zero value", result: Decimal{}.Add(NewDecimal(7)), output: "7"},
+		{scenario: "float value", result: decimalFromFloat(0.1), output: "0.1"},
+	}
+
+	t.Run(">>> validation of decimal operations", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+			t.Logf("scenario: %s", test.scenario)
+
+			if want, got := test.output, test.result.String(); want != got {
+				t.Errorf("fail in decimal operation: expected: %s result: %s", want, got)
+			}
+		}
+	})
+
+	t.Run(">>> validation of decimal comparison and conversion", func(t *testing.T) {
+
+		if decimalValue("0.30").Cmp(decimalValue("0.3")) != 0 || decimalValue("-1").Cmp(decimalValue("0.5")) >= 0 {
+			t.Errorf("fail in Cmp(): unexpected comparison results")
+		}
+
+		if intValue, fits := decimalValue("-12.9").Int64(); intValue != -12 || !fits {
+			t.Errorf("fail in Int64(): expected: -12 result: %d", intValue)
+		}
+
+		for _, value := range []string{"", "-", "1.2.3", "1e5", "abc"} {
+			if _, err := ParseDecimal(value); err == nil {
+				t.Errorf("fail in ParseDecimal(): expected error for: %s", value)
+			}
+		}
+	})
+}
@@ -0,0 +1,259 @@
+///////////////////////////////////////////////////////////////////////////////
+//	dedupeStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that removes duplicated rows
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//	constants for the record kept among duplicates
+const (
+	KEEP_FIRST = 1
+	KEEP_LAST  = 2
+	KEEP_BEST  = 3
+)
+
+var (
+	dedupe_keep = map[string]uint8{
+		"first": KEEP_FIRST,
+		"last":  KEEP_LAST,
+		"best":  KEEP_BEST,
+	}
+)
+
+//	attributes for a dedupe step configuration
+type DedupeConfig struct {
+	KeyFields  []string    `yaml:"key_fields"`
+	Keep       string      `yaml:"keep"`
+	OrderBy    []SortField `yaml:"order_by"`
+	Duplicates JobOutput   `yaml:"duplicates"`
+}
+
+//	attributes for a dedupe step
+type dedupeStep struct {
+	Config DedupeConfig
+
+	NextStep DataPipelineStep
+
+	keep          uint8
+	order         rowOrder
+	duplicates    DataOutputSink
+	rejecter      RowRejecter
+	seenKeys      map[string]bool
+	keptRows      map[string]*Row
+	keyOrder      []string
+	rowsKept      int64
+	rowsDiscarded int64
+}
+
+//	NewDedupeStep create a new dedupeStep, given the duplicates detection and survivorship rules and the input fields
+func NewDedupeStep(config DedupeConfig, inputFieldList []DataField) (DataPipelineStep, error) {
+
+	if len(config.Keep) == 0 {
+		config.Keep = "first"
+	}
+
+	keep, found := dedupe_keep[config.Keep]
+	if !found {
+		return nil, errors.New("Invalid dedupe keep rule: " + config.Keep)
+	}
+
+	//	the best record is the first one by the order_by fields
+	if keep == KEEP_BEST && len(config.OrderBy) == 0 {
+		return nil, errors.New("Dedupe keep rule best need order_by fields")
+	}
+
+	order, err := newRowOrder(config.OrderBy, inputFieldList)
+	if err != nil {
+		return nil, err
+	}
+
+	step := &dedupeStep{
+		Config:   config,
+		keep:     keep,
+		order:    order,
+		seenKeys: make(map[string]bool),
+		keptRows: make(map[string]*Row),
+	}
+
+	//	discarded duplicates can be written to their own output sink
+	if len(config.Duplicates.Type) > 0 {
+		step.duplicates, err = newDataOutputSink(config.Duplicates, inputFieldList)
+		if err != nil {
+			return nil, err
+		}
+
+		err = step.duplicates.ValidateFormat()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return step, nil
+}
+
+//	newDedupeStepFromConfig create a new dedupeStep from a job step configuration
+func newDedupeStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	var stepConfig DedupeConfig
+
+	err := config.Decode(&stepConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDedupeStep(stepConfig, job.Job.Input.FieldList)
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *dedupeStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *dedupeStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow pass the first row of each key to the next step, or keep the surviving row until the end of the job
+func (s *dedupeStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	key := s.rowKey(row)
+
+	if s.keep == KEEP_FIRST {
+		if s.seenKeys[key] {
+			return false, s.discard(row)
+		}
+		s.seenKeys[key] = true
+		s.rowsKept++
+
+		if s.NextStep != nil {
+			return s.NextStep.ProcessRow(row)
+		}
+
+		return true, nil
+	}
+
+	keptRow, found := s.keptRows[key]
+	if !found {
+		s.keptRows[key] = row.Copy()
+		s.keyOrder = append(s.keyOrder, key)
+
+		return true, nil
+	}
+
+	//	for equally good rows, the first one survives
+	if s.keep == KEEP_LAST || s.order.compare(row, keptRow) < 0 {
+		s.keptRows[key] = row.Copy()
+		return true, s.discard(keptRow)
+	}
+
+	return false, s.discard(row)
+}
+
+//	Start open the duplicates sink, if there's one
+func (s *dedupeStep) Start(job *JobContext) error {
+
+	s.rejecter = nil
+	if job != nil {
+		s.rejecter = job.Rejecter
+	}
+
+	if s.duplicates == nil {
+		return nil
+	}
+
+	return s.duplicates.Start(job)
+}
+
+//	Finish send the surviving rows to the next step, in the order their keys were first found, and finish the duplicates sink
+func (s *dedupeStep) Finish(jobErr error) error {
+
+	var err error
+
+	if jobErr == nil {
+		for _, key := range s.keyOrder {
+			s.rowsKept++
+
+			err = forwardRow(s.NextStep, s.rejecter, s.keptRows[key])
+			if err != nil {
+				jobErr = err
+				break
+			}
+		}
+	}
+	s.keyOrder = nil
+	s.keptRows = make(map[string]*Row)
+
+	if s.duplicates != nil {
+		finishErr := s.duplicates.Finish(jobErr)
+		if err == nil {
+			err = finishErr
+		}
+	}
+
+	return err
+}
+
+//	Summary report the number of rows kept and duplicates discarded
+func (s *dedupeStep) Summary() string {
+
+	key := strings.Join(s.Config.KeyFields, ", ")
+	if len(key) == 0 {
+		key = "all fields"
+	}
+
+	return fmt.Sprintf("Dedupe %s: %d rows kept, %d duplicates discarded", key, s.rowsKept, s.rowsDiscarded)
+}
+
+//	discard write a duplicated row to the duplicates sink, if there's one
+func (s *dedupeStep) discard(row *Row) error {
+
+	s.rowsDiscarded++
+
+	if s.duplicates == nil {
+		return nil
+	}
+
+	_, err := s.duplicates.ProcessRow(row)
+	if err != nil {
+		return NewFatalError(errors.New("fail writing duplicated row: " + err.Error()))
+	}
+
+	return nil
+}
+
+//	rowKey get the row's key fields values or, when there are no key fields, a hash of all the row's fields
+func (s *dedupeStep) rowKey(row *Row) string {
+
+	if len(s.Config.KeyFields) > 0 {
+		return lookupKey(row, s.Config.KeyFields)
+	}
+
+	fieldList := append([]string{}, row.Fields()...)
+	sort.Strings(fieldList)
+
+	hash := sha256.New()
+	for _, name := range fieldList {
+		hash.Write([]byte(name))
+		hash.Write([]byte(lookupKeySeparator))
+
+		//	nulls are told apart from empty strings
+		if row.Get(name) == nil {
+			hash.Write([]byte("\x01"))
+		} else {
+			hash.Write([]byte(row.GetString(name)))
+		}
+		hash.Write([]byte(lookupKeySeparator))
+	}
+
+	return string(hash.Sum(nil))
+}
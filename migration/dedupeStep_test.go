@@ -0,0 +1,136 @@
+///////////////////////////////////////////////////////////////////////////////
+//	dedupeStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the dedupe pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+//	Test_DedupeStep_ProcessRow test cases for the dedupe step
+func Test_DedupeStep_ProcessRow(t *testing.T) {
+
+	const duplicatesFileName = "testDuplicates.sql"
+
+	inputFieldList := []DataField{
+		{Name: "id", Type: "integer"},
+		{Name: "document", Type: "string"},
+		{Name: "updated", Type: "string"},
+	}
+
+	rowList := []map[string]string{
+		{"id": "1", "document": "111", "updated": "20230105"},
+		{"id": "2", "document": "222", "updated": "20230101"},
+		{"id": "3", "document": "111", "updated": "20230110"},
+		{"id": "4", "document": "111", "updated": "20230102"},
+		{"id": "2", "document": "222", "updated": "20230101"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario   string
+		config     DedupeConfig
+		output     string
+		duplicates string
+	}{
+		{scenario: "keep first", config: DedupeConfig{KeyFields: []string{"document"}},
+			output: "1 2", duplicates: "3 4 2"},
+		{scenario: "keep last", config: DedupeConfig{KeyFields: []string{"document"}, Keep: "last"},
+			output: "4 2", duplicates: "1 3 2"},
+		{scenario: "keep best", config: DedupeConfig{KeyFields: []string{"document"}, Keep: "best",
+			OrderBy: []SortField{{Name: "updated", Order: "desc"}}},
+			output: "3 2", duplicates: "1 4 2"},
+		{scenario: "full row hash", config: DedupeConfig{},
+			output: "1 2 3 4", duplicates: "2"},
+	}
+
+	t.Run(">>> validation of dedupe step", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			collector := &rowCollector{}
+
+			test.config.Duplicates = JobOutput{
+				Type:      "SQLScript",
+				FileName:  duplicatesFileName,
+				Dialect:   "postgres",
+				TableName: "duplicate",
+				FieldList: []DataField{{Name: "id", Type: "integer"}},
+			}
+
+			step, err := NewDedupeStep(test.config, inputFieldList)
+			if err != nil {
+				t.Errorf("unexpected error in NewDedupeStep(): %s", err)
+				continue
+			}
+			step.SetNextStep(collector)
+
+			err = startPipeline(step, nil)
+			if err != nil {
+				t.Errorf("unexpected error in Start(): %s", err)
+				continue
+			}
+
+			for _, row := range rowList {
+				_, err = step.ProcessRow(newTestRow(row))
+				if err != nil {
+					t.Errorf("unexpected error in ProcessRow(): %s", err)
+				}
+			}
+
+			err = finishPipeline(step, nil)
+			if err != nil {
+				t.Errorf("unexpected error in Finish(): %s", err)
+			}
+
+			var idList []string
+			for _, row := range collector.rows {
+				idList = append(idList, row.GetString("id"))
+			}
+
+			got := strings.Join(idList, " ")
+			if test.output != got {
+				t.Errorf("fail in ProcessRow(): expected: %s result: %s", test.output, got)
+			}
+
+			duplicates, err := os.ReadFile(duplicatesFileName)
+			if err != nil {
+				t.Errorf("unexpected error reading duplicates file: %s", err)
+			}
+			os.Remove(duplicatesFileName)
+
+			var duplicateList []string
+			for _, statement := range strings.Split(strings.TrimSpace(string(duplicates)), "\n") {
+				duplicateList = append(duplicateList, strings.TrimSuffix(strings.TrimPrefix(statement, "INSERT INTO duplicate (id) VALUES ("), ");"))
+			}
+
+			got = strings.Join(duplicateList, " ")
+			if test.duplicates != got {
+				t.Errorf("fail in ProcessRow(): expected duplicates: %s result: %s", test.duplicates, got)
+			}
+		}
+	})
+
+	t.Run(">>> validation of best record without ordering", func(t *testing.T) {
+
+		got := ""
+		want := "Dedupe keep rule best need order_by fields"
+
+		_, err := NewDedupeStep(DedupeConfig{KeyFields: []string{"document"}, Keep: "best"}, inputFieldList)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in NewDedupeStep(): expected: %s result: %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+///////////////////////////////////////////////////////////////////////////////
+//	deriveStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that creates or overwrites fields from expressions
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"time"
+)
+
+//	attributes for a derived field
+type DerivedField struct {
+	Name       string `yaml:"name"`
+	Type       string `yaml:"type"`
+	Expression string `yaml:"expression"`
+}
+
+//	attributes for a derive step
+type deriveStep struct {
+	FieldList []DerivedField
+
+	NextStep DataPipelineStep
+
+	expressionList []*Expression
+	fieldTypes     map[string]uint8
+}
+
+//	NewDeriveStep create a new deriveStep, given the fields to derive and the input fields
+func NewDeriveStep(fieldList []DerivedField, inputFieldList []DataField) (DataPipelineStep, error) {
+
+	step := &deriveStep{
+		FieldList:  fieldList,
+		fieldTypes: make(map[string]uint8),
+	}
+
+	//	there must be at least one field
+	if len(fieldList) == 0 {
+		return nil, errors.New("Derive step need at least one field")
+	}
+
+	for _, field := range inputFieldList {
+		step.fieldTypes[field.Name] = data_field_type[field.Type]
+	}
+
+	for _, field := range fieldList {
+		if len(field.Name) == 0 {
+			return nil, errors.New("Missing derived field name")
+		}
+
+		//	without a type, the field keeps the expression's value type
+		fieldType, found := data_field_type[field.Type]
+		if !found && len(field.Type) > 0 {
+			return nil, errors.New("Invalid field type: " + field.Type)
+		}
+
+		expression, err := ParseExpression(field.Expression)
+		if err != nil {
+			return nil, err
+		}
+
+		step.expressionList = append(step.expressionList, expression)
+		step.fieldTypes[field.Name] = fieldType
+	}
+
+	return step, nil
+}
+
+//	newDeriveStepFromConfig create a new deriveStep from a job step configuration
+func newDeriveStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	var stepConfig struct {
+		FieldList []DerivedField `yaml:"fields"`
+	}
+
+	err := config.Decode(&stepConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDeriveStep(stepConfig.FieldList, job.Job.Input.FieldList)
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *deriveStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *deriveStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow evaluate the expressions and set the derived fields in the data row
+func (s *deriveStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	for i, field := range s.FieldList {
+		value, err := s.expressionList[i].Evaluate(row, s.fieldTypes)
+		if err != nil {
+			return false, errors.New("fail deriving field " + field.Name + ": " + err.Error())
+		}
+
+		typedValue, err := derivedValue(s.fieldTypes[field.Name], value)
+		if err != nil {
+			return false, errors.New("Invalid " + field.Type + " value for field " + field.Name + ": " + exprString(value))
+		}
+
+		row.Set(field.Name, typedValue)
+	}
+
+	//	if available, invoke the next step in the pipeline
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	ConcurrentSafe the derived fields only depend on the row, so rows can be derived concurrently
+func (s *deriveStep) ConcurrentSafe() bool {
+	return true
+}
+
+//	derivedValue convert an expression value to the derived field's type: string fields get the value's text, texts
+//	are read as values of the field's type, integers are also decimals, and the other values must have the field's type
+func derivedValue(fieldType uint8, value interface{}) (interface{}, error) {
+
+	if value == nil || fieldType == 0 {
+		return value, nil
+	}
+
+	if fieldType == STRING {
+		return exprString(value), nil
+	}
+
+	if text, isString := value.(string); isString {
+		return parseFieldValue(fieldType, "", text)
+	}
+
+	valid := false
+
+	switch typedValue := value.(type) {
+	case int64:
+		if fieldType == DECIMAL {
+			return NewDecimal(typedValue), nil
+		}
+		valid = fieldType == INTEGER
+
+	case Decimal:
+		valid = fieldType == DECIMAL
+
+	case time.Time:
+		valid = fieldType == DATE || fieldType == TIMESTAMP
+
+	case bool:
+		valid = fieldType == BOOLEAN
+	}
+
+	if !valid {
+		return nil, errors.New("Invalid value type")
+	}
+
+	return value, nil
+}
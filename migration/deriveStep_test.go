@@ -0,0 +1,121 @@
+///////////////////////////////////////////////////////////////////////////////
+//	deriveStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the derive pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"fmt"
+	"testing"
+)
+
+//	Test_DeriveStep_ProcessRow test cases for the derive step
+func Test_DeriveStep_ProcessRow(t *testing.T) {
+
+	t.Run(">>> validation of derived fields", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		step, err := NewDeriveStep([]DerivedField{
+			{Name: "full_name", Expression: "trim(first) + ' ' + trim(last)"},
+			{Name: "sequence", Type: "integer", Expression: "sequence * 10"},
+			{Name: "label", Expression: "full_name + '#' + sequence"},
+		}, []DataField{{Name: "sequence", Type: "integer"}})
+		if err != nil {
+			t.Errorf("unexpected error in NewDeriveStep(): %s", err)
+		}
+		step.SetNextStep(collector)
+
+		_, err = step.ProcessRow(newTestRow(map[string]string{"first": "John ", "last": " Doe", "sequence": "002"}))
+		if err != nil {
+			t.Errorf("unexpected error in ProcessRow(): %s", err)
+		}
+
+		got := collector.rows[0].GetString("label")
+		want := "John Doe#20"
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of invalid integer result", func(t *testing.T) {
+
+		step, _ := NewDeriveStep([]DerivedField{
+			{Name: "half", Type: "integer", Expression: "number(value) / 2"},
+		}, nil)
+
+		got := ""
+		want := "Invalid integer value for field half: 1.5"
+
+		_, err := step.ProcessRow(newTestRow(map[string]string{"value": "3"}))
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of typed derived fields", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		step, err := NewDeriveStep([]DerivedField{
+			{Name: "x", Expression: "number(amt) * 2"},
+			{Name: "total", Type: "decimal", Expression: "qty * 3"},
+			{Name: "day", Type: "date", Expression: "'2026-10-19'"},
+			{Name: "big", Type: "boolean", Expression: "x > 4"},
+		}, []DataField{{Name: "qty", Type: "integer"}})
+		if err != nil {
+			t.Errorf("unexpected error in NewDeriveStep(): %s", err)
+		}
+
+		filter, err := NewFilterStep("x > 4 and big and day = add_days('2026-10-18', 1)", "keep", nil)
+		if err != nil {
+			t.Errorf("unexpected error in NewFilterStep(): %s", err)
+		}
+		step.SetNextStep(filter)
+		filter.SetNextStep(collector)
+
+		_, err = step.ProcessRow(newTestRow(map[string]string{"amt": "5.00", "qty": "2"}))
+		if err != nil {
+			t.Errorf("unexpected error in ProcessRow(): %s", err)
+		}
+
+		got := ""
+		want := "migration.Decimal 10.00 migration.Decimal 6 time.Time bool"
+
+		if len(collector.rows) == 1 {
+			row := collector.rows[0]
+			got = fmt.Sprintf("%T %s %T %s %T %T", row.Get("x"), row.GetString("x"), row.Get("total"), row.GetString("total"),
+				row.Get("day"), row.Get("big"))
+		}
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of invalid typed results", func(t *testing.T) {
+
+		step, _ := NewDeriveStep([]DerivedField{
+			{Name: "flag", Type: "boolean", Expression: "value"},
+		}, nil)
+
+		got := ""
+		want := "Invalid boolean value for field flag: 3"
+
+		_, err := step.ProcessRow(newTestRow(map[string]string{"value": "3"}))
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+		}
+	})
+}
@@ -7,8 +7,10 @@
 package migration
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -16,71 +18,283 @@ import (
 const (
 	FIXED_POSITION_FILE = 1
 	CSV_FILE            = 2
+	SQL_TABLE           = 3
+	SQL_SCRIPT          = 4
 )
 
 var (
 	io_type = map[string]uint8{
 		"FixedPositionFile": FIXED_POSITION_FILE,
 		"CSVFile":           CSV_FILE,
+		"SQLTable":          SQL_TABLE,
+		"SQLScript":         SQL_SCRIPT,
 	}
 )
 
 //	constants for data field types
 const (
-	INTEGER = 1
-	STRING  = 2
+	INTEGER   = 1
+	STRING    = 2
+	DECIMAL   = 3
+	DATE      = 4
+	TIMESTAMP = 5
+	BOOLEAN   = 6
 )
 
 var (
 	data_field_type = map[string]uint8{
-		"integer": INTEGER,
-		"string":  STRING,
+		"integer":   INTEGER,
+		"string":    STRING,
+		"decimal":   DECIMAL,
+		"date":      DATE,
+		"timestamp": TIMESTAMP,
+		"boolean":   BOOLEAN,
 	}
 )
 
-//	PerformMigration perform a migration configured by the DataMigration object
-func (dmig *DataMigration) PerformMigration() error {
+//	PerformMigration perform a migration configured by the DataMigration object; when the context is cancelled,
+//	the running jobs stop reading their inputs and finish their outputs, and the jobs not started are skipped
+func (dmig *DataMigration) PerformMigration(ctx context.Context) error {
 
-	fmt.Fprintf(os.Stdout, ">>> Starting Migration: %s\n", dmig.Description)
+	//	when data is written to stdout, informational messages go to stderr
+	var messages io.Writer = os.Stdout
 
-	for _, job := range dmig.JobList {
+	if dmig.writesToStdout() {
+		messages = os.Stderr
+	}
+
+	maxParallel, err := validateMaxParallelJobs(dmig.MaxParallelJobs)
+	if err != nil {
+		return err
+	}
+
+	graph, err := newJobGraph(dmig.JobList)
+	if err != nil {
+		return err
+	}
 
-		fmt.Fprintf(os.Stdout, "\nMigration Job: %s\n", job.Name)
+	fmt.Fprintf(messages, ">>> Starting Migration: %s\n", dmig.Description)
+
+	//	independent jobs run at the same time; the jobs depending on a failed job are skipped
+	graph.run(ctx, dmig.performJob, maxParallel, messages)
+
+	if ctx.Err() != nil {
+		fmt.Fprintf(messages, "\n>>> Migration interrupted: %s\n", ctx.Err())
+	}
+	graph.writeStatus(messages)
+
+	return graph.err()
+}
+
+//	performJob perform a migration job: import the input data through the job's pipeline
+func (dmig *DataMigration) performJob(ctx context.Context, job *MigrationJob, messages io.Writer) error {
+
+	fmt.Fprintf(messages, "\nMigration Job: %s\n", job.Name)
+
+	input, err := newDataInputSource(job.Input)
+	if err != nil {
+		return err
+	}
+
+	err = input.ValidateFormat()
+	if err != nil {
+		return err
+	}
+
+	//	when the job has an output, it's the last step in the pipeline
+	var output DataOutputSink
+
+	if len(job.Output.Type) > 0 {
+		output, err = newDataOutputSink(job.Output, job.Input.FieldList)
+		if err != nil {
+			return err
+		}
 
-		//check job's input type
-		inputType, found := io_type[job.Input.Type]
-		if !found {
-			return errors.New("Invalid job's input type: " + job.Input.Type)
+		err = output.ValidateFormat()
+		if err != nil {
+			return err
 		}
+	}
 
-		var input DataInputSource
+	//	rows the pipeline fails to process are rejected
+	rejects := NewRejectFile(job.Reject)
 
-		switch inputType {
-		case FIXED_POSITION_FILE:
-			input = NewFixedPositionInputFile(job.Input)
+	err = rejects.ValidateFormat()
+	if err != nil {
+		return err
+	}
+	input.SetRowRejecter(rejects)
 
-		case CSV_FILE:
-			input = NewCSVInputFile(job.Input)
+	jobContext := &JobContext{
+		Job:      job,
+		Messages: messages,
+		Rejecter: rejects,
+		Context:  ctx,
+	}
+
+	//	with checkpoints, a failed job can resume after the last row saved by the previous run
+	var checkpoint *jobCheckpoint
+
+	if len(job.Checkpoint.FileName) > 0 {
+		checkpoint = NewJobCheckpoint(job.Checkpoint, job.Name)
+
+		err = checkpoint.ValidateFormat()
+		if err != nil {
+			return err
 		}
 
-		err := input.ValidateFormat()
+		if dmig.Resume {
+			err = checkpoint.Load()
+		} else {
+			err = checkpoint.Reset()
+		}
 		if err != nil {
 			return err
 		}
 
-		var nextStep DataPipelineStep
+		if checkpoint.Completed() {
+			fmt.Fprintf(messages, "Job already finished in a previous run\n")
+			return nil
+		}
+
+		state := checkpoint.State()
+		if state != nil {
+			fmt.Fprintf(messages, "Resuming from checkpoint: %s, line %d\n", state.Input.FileName, state.Input.LineNumber)
 
-		if job.Trace {
-			nextStep = NewTraceDataStep(job.Trace)
+			jobContext.Resume = state
+			rejects.Resume(state.RejectSize, state.RowsRejected)
 		}
+		input.SetCheckpointer(checkpoint)
+
+		//	the partial output files of a failed job are kept, so it can resume
+		jobContext.Resumable = true
+	}
 
-		rowsProcessed, err := input.ImportData(nextStep)
+	nextStep, err := buildPipeline(jobContext, output)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint != nil {
+		err = checkpoint.Attach(nextStep, output, rejects)
 		if err != nil {
 			return err
 		}
+	}
+
+	err = startPipeline(nextStep, jobContext)
+	if err != nil {
+		return err
+	}
 
-		fmt.Fprintf(os.Stdout, "Job finished: %d rows processed\n", rowsProcessed)
+	var rowsProcessed int64
+
+	err = rejects.Open(jobContext)
+	if err == nil {
+		rowsProcessed, err = input.ImportData(ctx, nextStep)
+	}
+
+	//	the steps before the output are finished first, so the rows still in the workers and the rows rejected
+	//	when the steps finish count in the error rate before the output keeps the rows
+	finishErr := finishSteps(nextStep, output, err)
+	if err == nil {
+		err = finishErr
+	}
+	if err == nil {
+		err = rejects.CheckErrorRate(rowsProcessed)
+	}
+
+	//	the pipeline is finished even when the job failed, so the steps can release their resources
+	finishErr = finishSteps(output, nil, err)
+	if err == nil {
+		err = finishErr
 	}
 
+	closeErr := rejects.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil && checkpoint != nil {
+		err = checkpoint.Complete()
+	}
+
+	//	an interrupted job reports the rows processed before it stopped
+	if err != nil && ctx.Err() != nil {
+		fmt.Fprintf(messages, "Job interrupted: %d rows processed\n", rowsProcessed)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, processedFile := range input.ProcessedFiles() {
+		fmt.Fprintf(messages, "File %s: %d rows processed\n", processedFile.Name, processedFile.Rows)
+	}
+	if rejects.RowsRejected() > 0 {
+		fmt.Fprintf(messages, "Rows rejected: %d\n", rejects.RowsRejected())
+	}
+	for step := nextStep; step != nil; step = step.GetNextStep() {
+		summaryStep, ok := step.(DataPipelineStepSummary)
+		if ok {
+			fmt.Fprintf(messages, "%s\n", summaryStep.Summary())
+		}
+	}
+	fmt.Fprintf(messages, "Job finished: %d rows processed\n", rowsProcessed)
+
 	return nil
 }
+
+//	newDataInputSource create the input source for a job's input configuration
+func newDataInputSource(config JobInput) (DataInputSource, error) {
+
+	//check job's input type
+	inputType, found := io_type[config.Type]
+	if !found {
+		return nil, errors.New("Invalid job's input type: " + config.Type)
+	}
+
+	switch inputType {
+	case FIXED_POSITION_FILE:
+		return NewFixedPositionInputFile(config), nil
+
+	case CSV_FILE:
+		return NewCSVInputFile(config), nil
+	}
+
+	return nil, errors.New("Unsupported job's input type: " + config.Type)
+}
+
+//	newDataOutputSink create the output sink for a job's output configuration
+func newDataOutputSink(config JobOutput, inputFieldList []DataField) (DataOutputSink, error) {
+
+	outputType, found := io_type[config.Type]
+	if !found {
+		return nil, errors.New("Invalid job's output type: " + config.Type)
+	}
+
+	//	if no output fields are given, all input fields are written
+	if len(config.FieldList) == 0 {
+		config.FieldList = inputFieldList
+	}
+
+	switch outputType {
+	case SQL_TABLE:
+		return NewSQLTableOutput(config), nil
+
+	case SQL_SCRIPT:
+		return NewSQLScriptOutput(config), nil
+	}
+
+	return nil, errors.New("Unsupported job's output type: " + config.Type)
+}
+
+//	writesToStdout check if any job writes its output data to stdout
+func (dmig *DataMigration) writesToStdout() bool {
+
+	for _, job := range dmig.JobList {
+		if len(job.Output.Type) > 0 && job.Output.FileName == STANDARD_STREAM {
+			return true
+		}
+	}
+
+	return false
+}
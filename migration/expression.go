@@ -0,0 +1,928 @@
+///////////////////////////////////////////////////////////////////////////////
+//	expression.go  -  Oct-19-2026  -  aldebap
+//
+//	Parser and evaluator for row expressions
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+//	constants for expression token types
+const (
+	TOKEN_END        = 1
+	TOKEN_NUMBER     = 2
+	TOKEN_STRING     = 3
+	TOKEN_IDENTIFIER = 4
+	TOKEN_OPERATOR   = 5
+)
+
+//	attributes for an expression token
+type exprToken struct {
+	Type  uint8
+	Value string
+}
+
+//	node of a parsed expression
+type exprNode interface {
+	eval(env *exprEnv) (interface{}, error)
+}
+
+//	attributes for the evaluation environment: the row and the data types of its fields
+type exprEnv struct {
+	row        *Row
+	fieldTypes map[string]uint8
+}
+
+//	attributes for a parsed expression
+type Expression struct {
+	Source string
+
+	root exprNode
+}
+
+//	ParseExpression parse an expression source
+func ParseExpression(source string) (*Expression, error) {
+
+	tokenList, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, errors.New("Invalid expression: " + source + ": " + err.Error())
+	}
+
+	parser := &exprParser{tokenList: tokenList}
+
+	root, err := parser.parseOr()
+	if err == nil && parser.peek().Type != TOKEN_END {
+		err = errors.New("unexpected token: " + parser.peek().Value)
+	}
+	if err != nil {
+		return nil, errors.New("Invalid expression: " + source + ": " + err.Error())
+	}
+
+	return &Expression{Source: source, root: root}, nil
+}
+
+//	Evaluate evaluate the expression for a row, given the data types of its fields
+func (e *Expression) Evaluate(row *Row, fieldTypes map[string]uint8) (interface{}, error) {
+
+	return e.root.eval(&exprEnv{row: row, fieldTypes: fieldTypes})
+}
+
+//	tokenizeExpression split an expression source into tokens
+func tokenizeExpression(source string) ([]exprToken, error) {
+
+	var tokenList []exprToken
+
+	input := []rune(source)
+
+	for i := 0; i < len(input); {
+		c := input[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case unicode.IsDigit(c):
+			start := i
+			for i < len(input) && (unicode.IsDigit(input[i]) || input[i] == '.') {
+				i++
+			}
+			tokenList = append(tokenList, exprToken{Type: TOKEN_NUMBER, Value: string(input[start:i])})
+
+		case c == '\'':
+			var value strings.Builder
+
+			i++
+			for {
+				if i >= len(input) {
+					return nil, errors.New("unterminated string")
+				}
+				if input[i] == '\'' {
+					//	two quotes are an escaped quote
+					if i+1 < len(input) && input[i+1] == '\'' {
+						value.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				value.WriteRune(input[i])
+				i++
+			}
+			tokenList = append(tokenList, exprToken{Type: TOKEN_STRING, Value: value.String()})
+
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(input) && (unicode.IsLetter(input[i]) || unicode.IsDigit(input[i]) || input[i] == '_') {
+				i++
+			}
+			tokenList = append(tokenList, exprToken{Type: TOKEN_IDENTIFIER, Value: string(input[start:i])})
+
+		default:
+			//	two characters operators
+			if i+1 < len(input) {
+				operator := string(input[i : i+2])
+				if operator == "==" || operator == "!=" || operator == "<>" || operator == "<=" || operator == ">=" {
+					tokenList = append(tokenList, exprToken{Type: TOKEN_OPERATOR, Value: operator})
+					i += 2
+					continue
+				}
+			}
+
+			if !strings.ContainsRune("+-*/%()=<>,", c) {
+				return nil, errors.New("invalid character: " + string(c))
+			}
+			tokenList = append(tokenList, exprToken{Type: TOKEN_OPERATOR, Value: string(c)})
+			i++
+		}
+	}
+
+	return append(tokenList, exprToken{Type: TOKEN_END}), nil
+}
+
+//	attributes for a recursive descent expression parser
+type exprParser struct {
+	tokenList []exprToken
+	position  int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokenList[p.position]
+}
+
+func (p *exprParser) next() exprToken {
+
+	token := p.tokenList[p.position]
+	if token.Type != TOKEN_END {
+		p.position++
+	}
+
+	return token
+}
+
+//	isKeyword check if the next token is the given keyword (case insensitive)
+func (p *exprParser) isKeyword(keyword string) bool {
+
+	token := p.peek()
+
+	return token.Type == TOKEN_IDENTIFIER && strings.EqualFold(token.Value, keyword)
+}
+
+//	isKeywordAt check if the token at the given offset from the current one is the given keyword
+func (p *exprParser) isKeywordAt(offset int, keyword string) bool {
+
+	if p.position+offset >= len(p.tokenList) {
+		return false
+	}
+	token := p.tokenList[p.position+offset]
+
+	return token.Type == TOKEN_IDENTIFIER && strings.EqualFold(token.Value, keyword)
+}
+
+//	isOperator check if the next token is one of the given operators
+func (p *exprParser) isOperator(operators ...string) bool {
+
+	token := p.peek()
+	if token.Type != TOKEN_OPERATOR {
+		return false
+	}
+
+	for _, operator := range operators {
+		if token.Value == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+//	expect consume the given operator or fail
+func (p *exprParser) expect(operator string) error {
+
+	if !p.isOperator(operator) {
+		return errors.New("expected " + operator)
+	}
+	p.next()
+
+	return nil
+}
+
+//	parseOr parse: and_expression { OR and_expression }
+func (p *exprParser) parseOr() (exprNode, error) {
+
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: "or", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+//	parseAnd parse: not_expression { AND not_expression }
+func (p *exprParser) parseAnd() (exprNode, error) {
+
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+		p.next()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: "and", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+//	parseNot parse: [ NOT ] comparison
+func (p *exprParser) parseNot() (exprNode, error) {
+
+	if p.isKeyword("not") {
+		p.next()
+
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return &unaryNode{operator: "not", operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+//	parseComparison parse: additive [ comparison_operator additive | IS [NOT] NULL | [NOT] IN ( list ) | [NOT] MATCHES regex ]
+func (p *exprParser) parseComparison() (exprNode, error) {
+
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.isOperator("=", "==", "!=", "<>", "<", "<=", ">", ">=") {
+		operator := p.next().Value
+
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		//	normalize equivalent operators
+		switch operator {
+		case "==":
+			operator = "="
+		case "<>":
+			operator = "!="
+		}
+
+		return &binaryNode{operator: operator, left: left, right: right}, nil
+	}
+
+	//	null checks
+	if p.isKeyword("is") {
+		p.next()
+
+		node := &isNullNode{operand: left}
+		if p.isKeyword("not") {
+			p.next()
+			node.negate = true
+		}
+
+		if !p.isKeyword("null") {
+			return nil, errors.New("expected null")
+		}
+		p.next()
+
+		return node, nil
+	}
+
+	negate := false
+	if p.isKeyword("not") && (p.isKeywordAt(1, "in") || p.isKeywordAt(1, "matches")) {
+		p.next()
+		negate = true
+	}
+
+	//	list of values
+	if p.isKeyword("in") {
+		p.next()
+
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+
+		node := &inNode{operand: left, negate: negate}
+
+		for {
+			value, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			node.valueList = append(node.valueList, value)
+
+			if !p.isOperator(",") {
+				break
+			}
+			p.next()
+		}
+
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	}
+
+	//	regular expression match
+	if p.isKeyword("matches") {
+		p.next()
+
+		token := p.next()
+		if token.Type != TOKEN_STRING {
+			return nil, errors.New("expected regular expression string")
+		}
+
+		pattern, err := regexp.Compile(token.Value)
+		if err != nil {
+			return nil, errors.New("invalid regular expression: " + token.Value)
+		}
+
+		return &matchesNode{operand: left, pattern: pattern, negate: negate}, nil
+	}
+
+	return left, nil
+}
+
+//	parseAdditive parse: multiplicative { (+|-) multiplicative }
+func (p *exprParser) parseAdditive() (exprNode, error) {
+
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("+", "-") {
+		operator := p.next().Value
+
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+//	parseMultiplicative parse: unary { (*|/|%) unary }
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("*", "/", "%") {
+		operator := p.next().Value
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+//	parseUnary parse: [ - ] primary
+func (p *exprParser) parseUnary() (exprNode, error) {
+
+	if p.isOperator("-") {
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &unaryNode{operator: "-", operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+//	parsePrimary parse: number | string | true | false | null | function call | field | ( expression )
+func (p *exprParser) parsePrimary() (exprNode, error) {
+
+	token := p.next()
+
+	switch token.Type {
+	case TOKEN_NUMBER:
+		if strings.Contains(token.Value, ".") {
+			value, err := ParseDecimal(token.Value)
+			if err != nil {
+				return nil, errors.New("invalid number: " + token.Value)
+			}
+			return &literalNode{value: value}, nil
+		}
+
+		value, err := strconv.ParseInt(token.Value, 10, 64)
+		if err != nil {
+			//	integers that don't fit in an int64 are decimals
+			decimalValue, err := ParseDecimal(token.Value)
+			if err != nil {
+				return nil, errors.New("invalid number: " + token.Value)
+			}
+			return &literalNode{value: decimalValue}, nil
+		}
+		return &literalNode{value: value}, nil
+
+	case TOKEN_STRING:
+		return &literalNode{value: token.Value}, nil
+
+	case TOKEN_IDENTIFIER:
+		switch strings.ToLower(token.Value) {
+		case "true":
+			return &literalNode{value: true}, nil
+
+		case "false":
+			return &literalNode{value: false}, nil
+
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+
+		//	function call
+		if p.isOperator("(") {
+			p.next()
+
+			function := &functionNode{name: strings.ToLower(token.Value)}
+
+			definition, found := expr_function[function.name]
+			if !found {
+				return nil, errors.New("unknown function: " + token.Value)
+			}
+
+			if !p.isOperator(")") {
+				for {
+					arg, err := p.parseOr()
+					if err != nil {
+						return nil, err
+					}
+					function.args = append(function.args, arg)
+
+					if !p.isOperator(",") {
+						break
+					}
+					p.next()
+				}
+			}
+
+			err := p.expect(")")
+			if err != nil {
+				return nil, err
+			}
+
+			if len(function.args) < definition.minArgs || (definition.maxArgs >= 0 && len(function.args) > definition.maxArgs) {
+				return nil, errors.New("invalid number of arguments for function: " + token.Value)
+			}
+
+			return function, nil
+		}
+
+		return &fieldNode{name: token.Value}, nil
+
+	case TOKEN_OPERATOR:
+		if token.Value == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			err = p.expect(")")
+			if err != nil {
+				return nil, err
+			}
+
+			return node, nil
+		}
+	}
+
+	if token.Type == TOKEN_END {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	return nil, errors.New("unexpected token: " + token.Value)
+}
+
+//	literal value node
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(env *exprEnv) (interface{}, error) {
+	return n.value, nil
+}
+
+//	field reference node
+type fieldNode struct {
+	name string
+}
+
+func (n *fieldNode) eval(env *exprEnv) (interface{}, error) {
+
+	if !env.row.Has(n.name) {
+		return nil, errors.New("Unknown field in expression: " + n.name)
+	}
+
+	//	string values of typed fields are evaluated as values of the field's type
+	value, isString := env.row.Get(n.name).(string)
+	if !isString {
+		return env.row.Get(n.name), nil
+	}
+
+	typedValue, err := parseFieldValue(env.fieldTypes[n.name], "", value)
+	if err != nil {
+		return nil, errors.New("Invalid " + fieldTypeName(env.fieldTypes[n.name]) + " value for field " + n.name + ": " + strings.TrimSpace(value))
+	}
+
+	return typedValue, nil
+}
+
+//	unary operator node
+type unaryNode struct {
+	operator string
+	operand  exprNode
+}
+
+func (n *unaryNode) eval(env *exprEnv) (interface{}, error) {
+
+	value, err := n.operand.eval(env)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	if n.operator == "not" {
+		boolValue, err := exprBool(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return !boolValue, nil
+	}
+
+	switch number := value.(type) {
+	case int64:
+		if number == math.MinInt64 {
+			return NewDecimal(number).Neg(), nil
+		}
+		return -number, nil
+
+	case Decimal:
+		return number.Neg(), nil
+	}
+
+	return nil, errors.New("Invalid operand for operator -: " + exprString(value))
+}
+
+//	binary operator node
+type binaryNode struct {
+	operator string
+	left     exprNode
+	right    exprNode
+}
+
+func (n *binaryNode) eval(env *exprEnv) (interface{}, error) {
+
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	//	logical operators only evaluate the right operand when needed
+	switch n.operator {
+	case "and", "or":
+		leftBool, err := exprBool(left)
+		if err != nil {
+			return nil, err
+		}
+		if (n.operator == "and" && !leftBool) || (n.operator == "or" && leftBool) {
+			return leftBool, nil
+		}
+
+		right, err := n.right.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		return exprBool(right)
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.operator {
+	case "=", "!=", "<", "<=", ">", ">=":
+		return exprCompare(n.operator, left, right), nil
+	}
+
+	//	arithmetic with null results null
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	//	+ concatenates when any of the operands isn't a number
+	_, leftIsNumber := exprNumber(left)
+	_, rightIsNumber := exprNumber(right)
+
+	if n.operator == "+" && (!leftIsNumber || !rightIsNumber) {
+		return exprString(left) + exprString(right), nil
+	}
+
+	if !leftIsNumber || !rightIsNumber {
+		return nil, errors.New("Invalid operands for operator " + n.operator + ": " + exprString(left) + ", " + exprString(right))
+	}
+
+	return exprArithmetic(n.operator, left, right)
+}
+
+//	null check node
+type isNullNode struct {
+	operand exprNode
+	negate  bool
+}
+
+func (n *isNullNode) eval(env *exprEnv) (interface{}, error) {
+
+	value, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return exprIsNull(value) != n.negate, nil
+}
+
+//	list of values node
+type inNode struct {
+	operand   exprNode
+	valueList []exprNode
+	negate    bool
+}
+
+func (n *inNode) eval(env *exprEnv) (interface{}, error) {
+
+	value, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, listNode := range n.valueList {
+		listValue, err := listNode.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		if exprCompare("=", value, listValue) {
+			return !n.negate, nil
+		}
+	}
+
+	return n.negate, nil
+}
+
+//	regular expression match node
+type matchesNode struct {
+	operand exprNode
+	pattern *regexp.Regexp
+	negate  bool
+}
+
+func (n *matchesNode) eval(env *exprEnv) (interface{}, error) {
+
+	value, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return false, nil
+	}
+
+	return n.pattern.MatchString(exprString(value)) != n.negate, nil
+}
+
+//	function call node
+type functionNode struct {
+	name string
+	args []exprNode
+}
+
+func (n *functionNode) eval(env *exprEnv) (interface{}, error) {
+
+	definition := expr_function[n.name]
+
+	//	conditional functions evaluate their arguments lazily
+	if definition.lazy != nil {
+		return definition.lazy(env, n.args)
+	}
+
+	var args []interface{}
+
+	for _, arg := range n.args {
+		value, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
+	}
+
+	return definition.call(args)
+}
+
+//	exprNumber check if a value is a number, returning it as Decimal
+func exprNumber(value interface{}) (Decimal, bool) {
+
+	switch number := value.(type) {
+	case int64:
+		return NewDecimal(number), true
+
+	case Decimal:
+		return number, true
+	}
+
+	return Decimal{}, false
+}
+
+//	exprIsNull check if a value is null; blank strings are values, not nulls
+func exprIsNull(value interface{}) bool {
+	return value == nil
+}
+
+//	exprBool convert a value to boolean; null is false
+func exprBool(value interface{}) (bool, error) {
+
+	switch boolValue := value.(type) {
+	case nil:
+		return false, nil
+
+	case bool:
+		return boolValue, nil
+	}
+
+	return false, errors.New("Invalid boolean value: " + exprString(value))
+}
+
+//	exprString convert a value to string; null is an empty string
+func exprString(value interface{}) string {
+
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+
+	case string:
+		return typedValue
+
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+
+	case Decimal:
+		return typedValue.String()
+
+	case bool:
+		return strconv.FormatBool(typedValue)
+
+	case time.Time:
+		if typedValue.Hour() != 0 || typedValue.Minute() != 0 || typedValue.Second() != 0 {
+			return typedValue.Format(dateFormatReplacer.Replace(defaultTimestampFormat))
+		}
+		return typedValue.Format(dateFormatReplacer.Replace(defaultDateFormat))
+	}
+
+	return ""
+}
+
+//	exprCompare compare two values, numerically when both are numbers; null is only equal to null
+func exprCompare(operator string, left interface{}, right interface{}) bool {
+
+	var result int
+
+	if left == nil || right == nil {
+		switch operator {
+		case "=":
+			return left == nil && right == nil
+
+		case "!=":
+			return left != nil || right != nil
+		}
+
+		return false
+	}
+
+	leftNumber, leftIsNumber := exprNumber(left)
+	rightNumber, rightIsNumber := exprNumber(right)
+
+	if leftIsNumber && rightIsNumber {
+		result = leftNumber.Cmp(rightNumber)
+	} else {
+		result = strings.Compare(exprString(left), exprString(right))
+	}
+
+	switch operator {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	}
+
+	return result >= 0
+}
+
+//	exprArithmetic apply an arithmetic operator to two numbers, keeping integers when possible; results that don't
+//	fit in an integer are decimals
+func exprArithmetic(operator string, left interface{}, right interface{}) (interface{}, error) {
+
+	leftInt, leftIsInt := left.(int64)
+	rightInt, rightIsInt := right.(int64)
+
+	if leftIsInt && rightIsInt {
+		var result int64
+		fits := false
+
+		switch operator {
+		case "+":
+			result, fits = addInt64(leftInt, rightInt)
+		case "-":
+			result, fits = subInt64(leftInt, rightInt)
+		case "*":
+			result, fits = mulInt64(leftInt, rightInt)
+		case "/", "%":
+			if rightInt == 0 {
+				return nil, errors.New("Division by zero")
+			}
+			if operator == "%" {
+				return leftInt % rightInt, nil
+			}
+			if leftInt%rightInt == 0 && (leftInt != math.MinInt64 || rightInt != -1) {
+				return leftInt / rightInt, nil
+			}
+		}
+
+		if fits {
+			return result, nil
+		}
+	}
+
+	leftNumber, _ := exprNumber(left)
+	rightNumber, _ := exprNumber(right)
+
+	switch operator {
+	case "+":
+		return leftNumber.Add(rightNumber), nil
+	case "-":
+		return leftNumber.Sub(rightNumber), nil
+	case "*":
+		return leftNumber.Mul(rightNumber), nil
+	}
+
+	if rightNumber.Sign() == 0 {
+		return nil, errors.New("Division by zero")
+	}
+	if operator == "%" {
+		return nil, errors.New("Invalid operands for operator %: " + exprString(left) + ", " + exprString(right))
+	}
+
+	return leftNumber.Quo(rightNumber), nil
+}
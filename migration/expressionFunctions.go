@@ -0,0 +1,434 @@
+///////////////////////////////////////////////////////////////////////////////
+//	expressionFunctions.go  -  Oct-19-2026  -  aldebap
+//
+//	Functions available in row expressions
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//	attributes for an expression function: functions with lazy evaluation receive their arguments unevaluated
+type exprFunction struct {
+	minArgs int
+	maxArgs int
+	call    func(args []interface{}) (interface{}, error)
+	lazy    func(env *exprEnv, args []exprNode) (interface{}, error)
+}
+
+//	date format used when none is given to date functions
+const defaultDateFormat = "YYYY-MM-DD"
+
+//	format of the dates that have a time of day
+const defaultTimestampFormat = "YYYY-MM-DD HH:MI:SS"
+
+var (
+	expr_function = map[string]exprFunction{
+		"upper":        {minArgs: 1, maxArgs: 1, call: exprUpper},
+		"lower":        {minArgs: 1, maxArgs: 1, call: exprLower},
+		"trim":         {minArgs: 1, maxArgs: 1, call: exprTrim},
+		"ltrim":        {minArgs: 1, maxArgs: 1, call: exprLTrim},
+		"rtrim":        {minArgs: 1, maxArgs: 1, call: exprRTrim},
+		"substring":    {minArgs: 2, maxArgs: 3, call: exprSubstring},
+		"length":       {minArgs: 1, maxArgs: 1, call: exprLength},
+		"concat":       {minArgs: 1, maxArgs: -1, call: exprConcat},
+		"replace":      {minArgs: 3, maxArgs: 3, call: exprReplace},
+		"number":       {minArgs: 1, maxArgs: 1, call: exprToNumber},
+		"string":       {minArgs: 1, maxArgs: 1, call: exprToString},
+		"abs":          {minArgs: 1, maxArgs: 1, call: exprAbs},
+		"round":        {minArgs: 1, maxArgs: 2, call: exprRound},
+		"add_days":     {minArgs: 2, maxArgs: 3, call: exprAddDays},
+		"add_months":   {minArgs: 2, maxArgs: 3, call: exprAddMonths},
+		"days_between": {minArgs: 2, maxArgs: 3, call: exprDaysBetween},
+		"format_date":  {minArgs: 3, maxArgs: 3, call: exprFormatDate},
+		"coalesce":     {minArgs: 1, maxArgs: -1, lazy: exprCoalesce},
+		"if":           {minArgs: 2, maxArgs: 3, lazy: exprIf},
+		"case":         {minArgs: 2, maxArgs: -1, lazy: exprCase},
+	}
+
+	dateFormatReplacer = strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02",
+		"HH", "15", "MI", "04", "SS", "05")
+)
+
+//	exprStringFunction apply a string function to a single argument, null results null
+func exprStringFunction(args []interface{}, function func(string) string) (interface{}, error) {
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	return function(exprString(args[0])), nil
+}
+
+func exprUpper(args []interface{}) (interface{}, error) {
+	return exprStringFunction(args, strings.ToUpper)
+}
+
+func exprLower(args []interface{}) (interface{}, error) {
+	return exprStringFunction(args, strings.ToLower)
+}
+
+func exprTrim(args []interface{}) (interface{}, error) {
+	return exprStringFunction(args, strings.TrimSpace)
+}
+
+func exprLTrim(args []interface{}) (interface{}, error) {
+	return exprStringFunction(args, func(value string) string {
+		return strings.TrimLeft(value, " \t")
+	})
+}
+
+func exprRTrim(args []interface{}) (interface{}, error) {
+	return exprStringFunction(args, func(value string) string {
+		return strings.TrimRight(value, " \t")
+	})
+}
+
+//	exprInteger get an integer argument
+func exprInteger(function string, value interface{}) (int64, error) {
+
+	switch number := value.(type) {
+	case int64:
+		return number, nil
+
+	case Decimal:
+		if intValue, fits := number.Int64(); fits {
+			return intValue, nil
+		}
+	}
+
+	return 0, errors.New("Invalid integer argument for function " + function + ": " + exprString(value))
+}
+
+//	substring(value, start [, length]) with 1 based start position
+func exprSubstring(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	value := []rune(exprString(args[0]))
+
+	start, err := exprInteger("substring", args[1])
+	if err != nil {
+		return nil, err
+	}
+	if start < 1 {
+		start = 1
+	}
+	if start > int64(len(value)) {
+		return "", nil
+	}
+
+	end := int64(len(value))
+	if len(args) == 3 {
+		length, err := exprInteger("substring", args[2])
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			length = 0
+		}
+		if start-1+length < end {
+			end = start - 1 + length
+		}
+	}
+
+	return string(value[start-1 : end]), nil
+}
+
+func exprLength(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	return int64(len([]rune(exprString(args[0])))), nil
+}
+
+func exprConcat(args []interface{}) (interface{}, error) {
+
+	var result strings.Builder
+
+	for _, arg := range args {
+		result.WriteString(exprString(arg))
+	}
+
+	return result.String(), nil
+}
+
+func exprReplace(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	return strings.ReplaceAll(exprString(args[0]), exprString(args[1]), exprString(args[2])), nil
+}
+
+//	number(value) convert a string to an integer or decimal number; blank strings are null
+func exprToNumber(args []interface{}) (interface{}, error) {
+
+	if _, isNumber := exprNumber(args[0]); isNumber || args[0] == nil {
+		return args[0], nil
+	}
+
+	value := strings.TrimSpace(exprString(args[0]))
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		return intValue, nil
+	}
+
+	decimalValue, err := ParseDecimal(value)
+	if err != nil {
+		return nil, errors.New("Invalid number: " + value)
+	}
+
+	return decimalValue, nil
+}
+
+func exprToString(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	return exprString(args[0]), nil
+}
+
+func exprAbs(args []interface{}) (interface{}, error) {
+
+	switch number := args[0].(type) {
+	case nil:
+		return nil, nil
+
+	case int64:
+		if number == math.MinInt64 {
+			return NewDecimal(number).Abs(), nil
+		}
+		if number < 0 {
+			return -number, nil
+		}
+		return number, nil
+
+	case Decimal:
+		return number.Abs(), nil
+	}
+
+	return nil, errors.New("Invalid number argument for function abs: " + exprString(args[0]))
+}
+
+//	round(value [, digits])
+func exprRound(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	number, isNumber := exprNumber(args[0])
+	if !isNumber {
+		return nil, errors.New("Invalid number argument for function round: " + exprString(args[0]))
+	}
+
+	var digits int64
+
+	if len(args) == 2 {
+		var err error
+
+		digits, err = exprInteger("round", args[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if digits == 0 {
+		if intValue, fits := number.Round(0).Int64(); fits {
+			return intValue, nil
+		}
+	}
+
+	return number.Round(int32(digits)), nil
+}
+
+//	exprDateLayout convert a date format like YYYY-MM-DD to a Go time layout
+func exprDateLayout(args []interface{}, position int) string {
+
+	if len(args) > position {
+		return dateFormatReplacer.Replace(exprString(args[position]))
+	}
+
+	return dateFormatReplacer.Replace(defaultDateFormat)
+}
+
+//	exprDate parse a date argument
+func exprDate(function string, value interface{}, layout string) (time.Time, error) {
+
+	if date, isTime := value.(time.Time); isTime {
+		return date, nil
+	}
+
+	date, err := time.Parse(layout, strings.TrimSpace(exprString(value)))
+	if err != nil {
+		return date, errors.New("Invalid date argument for function " + function + ": " + exprString(value))
+	}
+
+	return date, nil
+}
+
+//	add_days(date, days [, format])
+func exprAddDays(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+
+	layout := exprDateLayout(args, 2)
+
+	date, err := exprDate("add_days", args[0], layout)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := exprInteger("add_days", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return date.AddDate(0, 0, int(days)).Format(layout), nil
+}
+
+//	add_months(date, months [, format])
+func exprAddMonths(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+
+	layout := exprDateLayout(args, 2)
+
+	date, err := exprDate("add_months", args[0], layout)
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := exprInteger("add_months", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return date.AddDate(0, int(months), 0).Format(layout), nil
+}
+
+//	days_between(from, to [, format])
+func exprDaysBetween(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+
+	layout := exprDateLayout(args, 2)
+
+	from, err := exprDate("days_between", args[0], layout)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := exprDate("days_between", args[1], layout)
+	if err != nil {
+		return nil, err
+	}
+
+	return int64(math.Round(to.Sub(from).Hours() / 24)), nil
+}
+
+//	format_date(date, from_format, to_format)
+func exprFormatDate(args []interface{}) (interface{}, error) {
+
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	date, err := exprDate("format_date", args[0], exprDateLayout(args, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return date.Format(exprDateLayout(args, 2)), nil
+}
+
+//	coalesce(value, ...) get the first value that is not null
+func exprCoalesce(env *exprEnv, args []exprNode) (interface{}, error) {
+
+	for _, arg := range args {
+		value, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exprIsNull(value) {
+			return value, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//	if(condition, value_if_true [, value_if_false])
+func exprIf(env *exprEnv, args []exprNode) (interface{}, error) {
+
+	condition, err := args[0].eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	conditionValue, err := exprBool(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if conditionValue {
+		return args[1].eval(env)
+	}
+	if len(args) == 3 {
+		return args[2].eval(env)
+	}
+
+	return nil, nil
+}
+
+//	case(condition_1, value_1, condition_2, value_2, ... [, default_value])
+func exprCase(env *exprEnv, args []exprNode) (interface{}, error) {
+
+	for i := 0; i+1 < len(args); i += 2 {
+		condition, err := args[i].eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		conditionValue, err := exprBool(condition)
+		if err != nil {
+			return nil, err
+		}
+
+		if conditionValue {
+			return args[i+1].eval(env)
+		}
+	}
+
+	//	an odd number of arguments means there's a default value
+	if len(args)%2 == 1 {
+		return args[len(args)-1].eval(env)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,148 @@
+///////////////////////////////////////////////////////////////////////////////
+//	expression_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for row expressions
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"fmt"
+	"testing"
+)
+
+//	Test_Expression_Evaluate test cases for expressions evaluation
+func Test_Expression_Evaluate(t *testing.T) {
+
+	row := newTestRow(map[string]string{
+		"first":      "  John ",
+		"last":       "O'Neil",
+		"amount":     "0150",
+		"rate":       "3",
+		"empty":      "   ",
+		"birth_date": "20000131",
+		"status":     "A",
+	})
+	row.Set("missing", nil)
+	fieldTypes := map[string]uint8{
+		"amount": INTEGER,
+		"rate":   INTEGER,
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario   string
+		expression string
+		output     string
+	}{
+		{scenario: "string concatenation", expression: "trim(first) + ' ' + trim(last)", output: "John O'Neil"},
+		{scenario: "escaped quote", expression: "'it''s'", output: "it's"},
+		{scenario: "integer arithmetic", expression: "amount * rate - 50", output: "400"},
+		{scenario: "operator precedence", expression: "(amount + 50) / 4 % 7", output: "1"},
+		{scenario: "decimal division", expression: "amount / 4", output: "37.5"},
+		{scenario: "unary minus", expression: "-amount + 1", output: "-149"},
+		{scenario: "substring", expression: "substring(last, 3, 2)", output: "Ne"},
+		{scenario: "substring to the end", expression: "substring(last, 3)", output: "Neil"},
+		{scenario: "upper and lower", expression: "upper(trim(first)) + lower(last)", output: "JOHNo'neil"},
+		{scenario: "length", expression: "length(trim(first))", output: "4"},
+		{scenario: "number conversion", expression: "number('12.5') * 2", output: "25.0"},
+		{scenario: "exact decimals", expression: "0.10 + 0.10 + 0.10", output: "0.30"},
+		{scenario: "decimal comparison", expression: "0.1 + 0.2 = 0.3", output: "true"},
+		{scenario: "round", expression: "round(10 / 3, 2)", output: "3.33"},
+		{scenario: "integer sum overflow", expression: "9223372036854775807 + 1", output: "9223372036854775808"},
+		{scenario: "integer difference overflow", expression: "-9223372036854775807 - 2", output: "-9223372036854775809"},
+		{scenario: "integer product overflow", expression: "4611686018427387904 * -4", output: "-18446744073709551616"},
+		{scenario: "integer literal overflow", expression: "9223372036854775808 - 1", output: "9223372036854775807"},
+		{scenario: "round half away from zero", expression: "round(-2.5) + round(1234, -2)", output: "1197"},
+		{scenario: "coalesce", expression: "coalesce(missing, null, trim(first))", output: "John"},
+		{scenario: "if true", expression: "if(amount > 100 and status = 'A', 'big', 'small')", output: "big"},
+		{scenario: "if false", expression: "if(amount > 100 and not status == 'A', 'big', 'small')", output: "small"},
+		{scenario: "lazy if", expression: "if(rate = 0, amount / rate, 0)", output: "0"},
+		{scenario: "case", expression: "case(status = 'I', 'inactive', status = 'A', 'active', 'unknown')", output: "active"},
+		{scenario: "case default", expression: "case(status <> 'A', 'other', 'default')", output: "default"},
+		{scenario: "add days", expression: "add_days(birth_date, 1, 'YYYYMMDD')", output: "20000201"},
+		{scenario: "add months", expression: "add_months('2000-01-31', 1)", output: "2000-03-02"},
+		{scenario: "days between", expression: "days_between('2000-01-01', '2000-03-01')", output: "60"},
+		{scenario: "format date", expression: "format_date(birth_date, 'YYYYMMDD', 'DD/MM/YYYY')", output: "31/01/2000"},
+		{scenario: "in list", expression: "status in ('A', 'B') and rate not in (1, 2)", output: "true"},
+		{scenario: "not in list", expression: "status not in ('A', 'B')", output: "false"},
+		{scenario: "matches", expression: "birth_date matches '^20[0-9]{6}$'", output: "true"},
+		{scenario: "not matches", expression: "last not matches '^O'", output: "false"},
+		{scenario: "is null", expression: "missing is null and first is not null", output: "true"},
+		{scenario: "blank is not null", expression: "empty is not null and empty = '   '", output: "true"},
+		{scenario: "or", expression: "status = 'I' or (rate >= 3 and amount <= 150)", output: "true"},
+		{scenario: "unknown field", expression: "xpto + 1", output: "Unknown field in expression: xpto"},
+		{scenario: "division by zero", expression: "amount / (rate - 3)", output: "Division by zero"},
+		{scenario: "invalid arithmetic", expression: "last * 2", output: "Invalid operands for operator *: O'Neil, 2"},
+	}
+
+	t.Run(">>> validation of expressions evaluation", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			expression, err := ParseExpression(test.expression)
+			if err != nil {
+				t.Errorf("unexpected error in ParseExpression(): %s", err)
+				continue
+			}
+
+			got := ""
+			want := test.output
+
+			value, err := expression.Evaluate(row, fieldTypes)
+			if err != nil {
+				got = err.Error()
+			} else {
+				got = exprString(value)
+			}
+
+			if want != got {
+				t.Errorf("fail in Evaluate(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_Expression_Parse test cases for invalid expressions
+func Test_Expression_Parse(t *testing.T) {
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario   string
+		expression string
+		output     string
+	}{
+		{scenario: "empty expression", expression: "", output: "Invalid expression: : unexpected end of expression"},
+		{scenario: "unterminated string", expression: "'abc", output: "Invalid expression: 'abc: unterminated string"},
+		{scenario: "invalid character", expression: "a & b", output: "Invalid expression: a & b: invalid character: &"},
+		{scenario: "unknown function", expression: "xpto(a)", output: "Invalid expression: xpto(a): unknown function: xpto"},
+		{scenario: "invalid arguments", expression: "upper(a, b)", output: "Invalid expression: upper(a, b): invalid number of arguments for function: upper"},
+		{scenario: "missing parenthesis", expression: "(a + b", output: "Invalid expression: (a + b: expected )"},
+		{scenario: "trailing tokens", expression: "a b", output: "Invalid expression: a b: unexpected token: b"},
+		{scenario: "invalid null check", expression: "a is 1", output: "Invalid expression: a is 1: expected null"},
+		{scenario: "invalid regex", expression: "a matches '['", output: "Invalid expression: a matches '[': invalid regular expression: ["},
+		{scenario: "missing regex", expression: "a matches b", output: "Invalid expression: a matches b: expected regular expression string"},
+	}
+
+	t.Run(">>> validation of invalid expressions", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			got := ""
+			want := test.output
+
+			_, err := ParseExpression(test.expression)
+			if err != nil {
+				got = err.Error()
+			}
+
+			if want != got {
+				t.Errorf("fail in ParseExpression(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
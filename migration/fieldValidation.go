@@ -0,0 +1,240 @@
+///////////////////////////////////////////////////////////////////////////////
+//	fieldValidation.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that checks the fields constraints for every row
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+//	error for a field value that breaks one of the field's constraints
+type FieldValidationError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (e *FieldValidationError) Error() string {
+	return "Invalid value for field " + e.Field + ": " + e.Reason
+}
+
+//	attributes of a field with constraints ready to be checked
+type fieldConstraints struct {
+	field      DataField
+	fieldType  uint8
+	min        interface{}
+	max        interface{}
+	pattern    *regexp.Regexp
+	allowed    map[string]bool
+	seenValues map[string]bool
+}
+
+//	attributes for a field validation step
+type fieldValidationStep struct {
+	FieldList []DataField
+
+	NextStep DataPipelineStep
+
+	constraintList []*fieldConstraints
+	rowsValid      int64
+	rowsInvalid    int64
+}
+
+//	hasConstraints check if a field has any constraint
+func (f *DataField) hasConstraints() bool {
+
+	return f.Required || len(f.Min) > 0 || len(f.Max) > 0 || f.MinLength > 0 || f.MaxLength > 0 ||
+		len(f.Pattern) > 0 || len(f.AllowedValues) > 0 || f.Unique
+}
+
+//	NewFieldValidationStep create a new fieldValidationStep for the constraints of the input fields
+func NewFieldValidationStep(fieldList []DataField) (DataPipelineStep, error) {
+
+	step := &fieldValidationStep{
+		FieldList: fieldList,
+	}
+
+	for _, field := range fieldList {
+		if !field.hasConstraints() {
+			continue
+		}
+
+		constraints := &fieldConstraints{
+			field:     field,
+			fieldType: data_field_type[field.Type],
+		}
+
+		//	min and max must be valid values for the field's type
+		var err error
+
+		constraints.min, err = fieldLimit(field, constraints.fieldType, field.Min)
+		if err != nil {
+			return nil, err
+		}
+		constraints.max, err = fieldLimit(field, constraints.fieldType, field.Max)
+		if err != nil {
+			return nil, err
+		}
+		if constraints.min != nil && constraints.max != nil && compareRowValues(constraints.min, constraints.max, constraints.fieldType) > 0 {
+			return nil, errors.New("Field min greater than max: " + field.Name)
+		}
+
+		if field.MinLength < 0 || field.MaxLength < 0 || (field.MaxLength > 0 && field.MinLength > field.MaxLength) {
+			return nil, errors.New("Invalid field length limits: " + field.Name)
+		}
+
+		if len(field.Pattern) > 0 {
+			pattern, err := regexp.Compile(field.Pattern)
+			if err != nil {
+				return nil, errors.New("Invalid field pattern: " + field.Name + ": " + err.Error())
+			}
+			constraints.pattern = pattern
+		}
+
+		if len(field.AllowedValues) > 0 {
+			constraints.allowed = make(map[string]bool)
+			for _, value := range field.AllowedValues {
+				constraints.allowed[value] = true
+			}
+		}
+
+		if field.Unique {
+			constraints.seenValues = make(map[string]bool)
+		}
+
+		step.constraintList = append(step.constraintList, constraints)
+	}
+
+	return step, nil
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *fieldValidationStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *fieldValidationStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow check the fields constraints and pass the row to the next step only when it's valid
+func (s *fieldValidationStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	for _, constraints := range s.constraintList {
+		err = constraints.check(row.Get(constraints.field.Name))
+		if err != nil {
+			atomic.AddInt64(&s.rowsInvalid, 1)
+			return false, err
+		}
+	}
+
+	//	unique values are only recorded for valid rows
+	for _, constraints := range s.constraintList {
+		if constraints.seenValues != nil {
+			constraints.seenValues[strings.TrimSpace(row.GetString(constraints.field.Name))] = true
+		}
+	}
+	atomic.AddInt64(&s.rowsValid, 1)
+
+	//	if available, invoke the next step in the pipeline
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	ConcurrentSafe rows can be checked concurrently, unless some field must be unique: the values seen so far
+//	depend on the rows order
+func (s *fieldValidationStep) ConcurrentSafe() bool {
+
+	for _, constraints := range s.constraintList {
+		if constraints.seenValues != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+//	Summary report the number of valid and invalid rows
+func (s *fieldValidationStep) Summary() string {
+
+	return fmt.Sprintf("Field validation: %d rows valid, %d rows invalid", s.rowsValid, s.rowsInvalid)
+}
+
+//	fieldLimit get a field's min or max limit as a value of the field's type; missing limits are null
+func fieldLimit(field DataField, fieldType uint8, limit string) (interface{}, error) {
+
+	if len(limit) == 0 {
+		return nil, nil
+	}
+
+	value, err := parseFieldValue(fieldType, field.Format, limit)
+	if err != nil {
+		return nil, errors.New("Invalid " + field.Type + " limit for field " + field.Name + ": " + limit)
+	}
+
+	return value, nil
+}
+
+//	check check a field value against the field's constraints; blank values are only checked for required fields
+func (c *fieldConstraints) check(fieldValue interface{}) error {
+
+	field := c.field.Name
+	value := strings.TrimSpace(exprString(fieldValue))
+
+	if len(value) == 0 {
+		if c.field.Required {
+			return &FieldValidationError{Field: field, Value: value, Reason: "required value missing"}
+		}
+		return nil
+	}
+
+	//	values still in text must be valid values for the field's type
+	if text, isString := fieldValue.(string); isString && c.fieldType != STRING {
+		typedValue, err := parseFieldValue(c.fieldType, c.field.Format, text)
+		if err != nil {
+			return &FieldValidationError{Field: field, Value: value, Reason: err.Error()}
+		}
+		fieldValue = typedValue
+	}
+
+	if c.min != nil && compareRowValues(fieldValue, c.min, c.fieldType) < 0 {
+		return &FieldValidationError{Field: field, Value: value, Reason: value + " less than " + c.field.Min}
+	}
+	if c.max != nil && compareRowValues(fieldValue, c.max, c.fieldType) > 0 {
+		return &FieldValidationError{Field: field, Value: value, Reason: value + " greater than " + c.field.Max}
+	}
+
+	length := len([]rune(value))
+	if c.field.MinLength > 0 && length < c.field.MinLength {
+		return &FieldValidationError{Field: field, Value: value, Reason: "length less than " + strconv.Itoa(c.field.MinLength)}
+	}
+	if c.field.MaxLength > 0 && length > c.field.MaxLength {
+		return &FieldValidationError{Field: field, Value: value, Reason: "length greater than " + strconv.Itoa(c.field.MaxLength)}
+	}
+
+	if c.pattern != nil && !c.pattern.MatchString(value) {
+		return &FieldValidationError{Field: field, Value: value, Reason: value + " doesn't match pattern " + c.field.Pattern}
+	}
+
+	if c.allowed != nil && !c.allowed[value] {
+		return &FieldValidationError{Field: field, Value: value, Reason: value + " not an allowed value"}
+	}
+
+	if c.seenValues != nil && c.seenValues[value] {
+		return &FieldValidationError{Field: field, Value: value, Reason: "duplicated value: " + value}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+///////////////////////////////////////////////////////////////////////////////
+//	fieldValidation_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the field validation pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"fmt"
+	"testing"
+)
+
+//	Test_FieldValidationStep_ProcessRow test cases for the fields constraints
+func Test_FieldValidationStep_ProcessRow(t *testing.T) {
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer", Required: true, Unique: true},
+		{Name: "age", Type: "integer", Min: "18", Max: "120"},
+		{Name: "name", Type: "string", MinLength: 2, MaxLength: 10},
+		{Name: "zip", Type: "string", Pattern: "^[0-9]{5}-[0-9]{3}$"},
+		{Name: "status", Type: "string", AllowedValues: []string{"A", "I"}},
+		{Name: "notes", Type: "string"},
+		{Name: "price", Type: "decimal", Min: "0.50", Max: "99.99"},
+		{Name: "since", Type: "date", Format: "DD/MM/YYYY", Min: "01/01/2026"},
+	}
+
+	//	a few test cases, processed in sequence by the same step
+	var testScenarios = []struct {
+		scenario string
+		input    map[string]string
+		output   string
+	}{
+		{scenario: "valid row", input: map[string]string{"id": "1", "age": " 30", "name": "Ann", "zip": "01234-567", "status": "A"},
+			output: "valid"},
+		{scenario: "blank optional fields", input: map[string]string{"id": "2", "age": "  ", "name": "", "zip": "", "status": ""},
+			output: "valid"},
+		{scenario: "required", input: map[string]string{"id": "   "},
+			output: "Invalid value for field id: required value missing"},
+		{scenario: "unique", input: map[string]string{"id": "1"},
+			output: "Invalid value for field id: duplicated value: 1"},
+		{scenario: "integer", input: map[string]string{"id": "3", "age": "3O"},
+			output: "Invalid value for field age: not an integer: 3O"},
+		{scenario: "min", input: map[string]string{"id": "3", "age": "9"},
+			output: "Invalid value for field age: 9 less than 18"},
+		{scenario: "max", input: map[string]string{"id": "3", "age": "121"},
+			output: "Invalid value for field age: 121 greater than 120"},
+		{scenario: "min length", input: map[string]string{"id": "3", "name": "A"},
+			output: "Invalid value for field name: length less than 2"},
+		{scenario: "max length", input: map[string]string{"id": "3", "name": "Maximiliano"},
+			output: "Invalid value for field name: length greater than 10"},
+		{scenario: "pattern", input: map[string]string{"id": "3", "zip": "01234567"},
+			output: "Invalid value for field zip: 01234567 doesn't match pattern ^[0-9]{5}-[0-9]{3}$"},
+		{scenario: "allowed values", input: map[string]string{"id": "3", "status": "X"},
+			output: "Invalid value for field status: X not an allowed value"},
+		{scenario: "decimal min", input: map[string]string{"id": "3", "price": "0.5", "since": "02/01/2026"},
+			output: "valid"},
+		{scenario: "decimal max", input: map[string]string{"id": "4", "price": "100.0"},
+			output: "Invalid value for field price: 100.0 greater than 99.99"},
+		{scenario: "date min", input: map[string]string{"id": "4", "since": "31/12/2025"},
+			output: "Invalid value for field since: 31/12/2025 less than 01/01/2026"},
+		{scenario: "date format", input: map[string]string{"id": "4", "since": "2026-01-02"},
+			output: "Invalid value for field since: not a date: 2026-01-02"},
+		{scenario: "invalid rows don't count as unique", input: map[string]string{"id": "4"},
+			output: "valid"},
+	}
+
+	step, err := NewFieldValidationStep(fieldList)
+	if err != nil {
+		t.Errorf("unexpected error in NewFieldValidationStep(): %s", err)
+		return
+	}
+
+	t.Run(">>> validation of fields constraints", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			got := "valid"
+			want := test.output
+
+			_, err := step.ProcessRow(newTestRow(test.input))
+			if err != nil {
+				got = err.Error()
+			}
+
+			if want != got {
+				t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+			}
+		}
+
+		want := "Field validation: 4 rows valid, 12 rows invalid"
+		got := step.(DataPipelineStepSummary).Summary()
+
+		if want != got {
+			t.Errorf("fail in Summary(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of invalid constraints", func(t *testing.T) {
+
+		got := ""
+		want := "Field min greater than max: age"
+
+		_, err := NewFieldValidationStep([]DataField{{Name: "age", Type: "integer", Min: "100", Max: "18"}})
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in NewFieldValidationStep(): expected: %s result: %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+///////////////////////////////////////////////////////////////////////////////
+//	filterStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that keeps or drops rows based on a condition
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+//	constants for filter actions
+const (
+	FILTER_KEEP = 1
+	FILTER_DROP = 2
+)
+
+var (
+	filter_action = map[string]uint8{
+		"keep": FILTER_KEEP,
+		"drop": FILTER_DROP,
+	}
+)
+
+//	attributes for a filter step
+type filterStep struct {
+	Condition string
+	Action    string
+
+	NextStep DataPipelineStep
+
+	expression  *Expression
+	keepMatches bool
+	fieldTypes  map[string]uint8
+	rowsKept    int64
+	rowsDropped int64
+}
+
+//	NewFilterStep create a new filterStep that keeps (or drops) the rows matching the condition
+func NewFilterStep(condition string, action string, inputFieldList []DataField) (DataPipelineStep, error) {
+
+	if len(action) == 0 {
+		action = "keep"
+	}
+
+	filterAction, found := filter_action[action]
+	if !found {
+		return nil, errors.New("Invalid filter action: " + action)
+	}
+
+	if len(condition) == 0 {
+		return nil, errors.New("Missing filter condition")
+	}
+
+	expression, err := ParseExpression(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	step := &filterStep{
+		Condition:   condition,
+		Action:      action,
+		expression:  expression,
+		keepMatches: filterAction == FILTER_KEEP,
+		fieldTypes:  make(map[string]uint8),
+	}
+
+	for _, field := range inputFieldList {
+		step.fieldTypes[field.Name] = data_field_type[field.Type]
+	}
+
+	return step, nil
+}
+
+//	newFilterStepFromConfig create a new filterStep from a job step configuration
+func newFilterStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	var stepConfig struct {
+		Condition string `yaml:"condition"`
+		Action    string `yaml:"action"`
+	}
+
+	err := config.Decode(&stepConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFilterStep(stepConfig.Condition, stepConfig.Action, job.Job.Input.FieldList)
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *filterStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *filterStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow evaluate the condition and pass the row to the next step only when it's kept
+func (s *filterStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	value, err := s.expression.Evaluate(row, s.fieldTypes)
+	if err != nil {
+		return false, errors.New("fail evaluating filter condition: " + err.Error())
+	}
+
+	matches, err := exprBool(value)
+	if err != nil {
+		return false, errors.New("fail evaluating filter condition: " + err.Error())
+	}
+
+	if matches != s.keepMatches {
+		atomic.AddInt64(&s.rowsDropped, 1)
+		return false, nil
+	}
+	atomic.AddInt64(&s.rowsKept, 1)
+
+	//	if available, invoke the next step in the pipeline
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	ConcurrentSafe the filter condition only reads the row, so rows can be filtered concurrently
+func (s *filterStep) ConcurrentSafe() bool {
+	return true
+}
+
+//	Summary report the number of rows kept and dropped
+func (s *filterStep) Summary() string {
+
+	return fmt.Sprintf("Filter %s: %d rows kept, %d rows dropped", s.Condition, s.rowsKept, s.rowsDropped)
+}
@@ -0,0 +1,102 @@
+///////////////////////////////////////////////////////////////////////////////
+//	filterStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the filter pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+)
+
+//	Test_FilterStep_ProcessRow test cases for the filter step
+func Test_FilterStep_ProcessRow(t *testing.T) {
+
+	const testFileName = "testData.txt"
+
+	err := os.WriteFile(testFileName, []byte("1,A,20230101\n2,I,20230102\n3,A,20220101\n4,A,20230201\n"), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove(testFileName)
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer"},
+		{Name: "status", Type: "string"},
+		{Name: "since", Type: "string"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario  string
+		condition string
+		action    string
+		output    string
+		summary   string
+	}{
+		{scenario: "keep active", condition: "status = 'A' and since >= '20230101'", output: "[1 4]",
+			summary: "Filter status = 'A' and since >= '20230101': 2 rows kept, 2 rows dropped"},
+		{scenario: "drop ids", condition: "id in (2, 3)", action: "drop", output: "[1 4]",
+			summary: "Filter id in (2, 3): 2 rows kept, 2 rows dropped"},
+		{scenario: "keep matching", condition: "since matches '^2022'", output: "[3]",
+			summary: "Filter since matches '^2022': 1 rows kept, 3 rows dropped"},
+	}
+
+	t.Run(">>> validation of filter step", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			collector := &rowCollector{}
+
+			step, err := NewFilterStep(test.condition, test.action, fieldList)
+			if err != nil {
+				t.Errorf("unexpected error in NewFilterStep(): %s", err)
+				continue
+			}
+			step.SetNextStep(collector)
+
+			testDataSource := NewCSVInputFile(JobInput{FileName: testFileName, FieldSeparator: ",", FieldList: fieldList})
+
+			_, err = testDataSource.ImportData(context.Background(), step)
+			if err != nil {
+				t.Errorf("unexpected error in ImportData(): %s", err)
+			}
+
+			var idList []string
+			for _, row := range collector.rows {
+				idList = append(idList, row.GetString("id"))
+			}
+
+			got := fmt.Sprint(idList)
+			if test.output != got {
+				t.Errorf("fail in ProcessRow(): expected: %s result: %s", test.output, got)
+			}
+
+			gotSummary := step.(DataPipelineStepSummary).Summary()
+			if test.summary != gotSummary {
+				t.Errorf("fail in Summary(): expected: %s result: %s", test.summary, gotSummary)
+			}
+		}
+	})
+
+	t.Run(">>> validation of invalid filter action", func(t *testing.T) {
+
+		got := ""
+		want := "Invalid filter action: xpto"
+
+		_, err := NewFilterStep("id = 1", "xpto", fieldList)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in NewFilterStep(): expected: %s result: %s", want, got)
+		}
+	})
+}
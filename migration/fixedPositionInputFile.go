@@ -7,28 +7,36 @@
 package migration
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
-	"os"
+	"strconv"
 )
 
 //	attributes for a fixed lenght input file
 type fixedPositionInputFile struct {
-	FileName  string
-	Header    bool
-	Trailer   bool
-	FieldList []DataField
+	FileName     string
+	FileNameList []string
+	Compression  string
+	Header       bool
+	Trailer      bool
+	FieldList    []DataField
+
+	processedFiles []ProcessedFile
+	rejecter       RowRejecter
+	checkpointer   InputCheckpointer
 }
 
 //	NewFixedPositionInputFile create a new FixedPositionInputFile
 func NewFixedPositionInputFile(config JobInput) DataInputSource {
 
 	return &fixedPositionInputFile{
-		FileName:  config.FileName,
-		Header:    config.Header,
-		Trailer:   config.Trailer,
-		FieldList: config.FieldList,
+		FileName:     config.FileName,
+		FileNameList: config.FileNameList,
+		Compression:  config.Compression,
+		Header:       config.Header,
+		Trailer:      config.Trailer,
+		FieldList:    config.FieldList,
 	}
 }
 
@@ -80,50 +88,79 @@ func (f *fixedPositionInputFile) ValidateFormat() error {
 		}
 	}
 
-	return nil
+	return f.inputFileSet().validate()
 }
 
-//	ImportData open fixed position file and import its data
-func (f *fixedPositionInputFile) ImportData(nextStep DataPipelineStep) (rowsProcessed int64, err error) {
+//	ImportData open the data files (or each archive member they point to) and import their data
+func (f *fixedPositionInputFile) ImportData(ctx context.Context, nextStep DataPipelineStep) (rowsProcessed int64, err error) {
 
-	//	 open fixed position file
-	dataFile, err := os.Open(f.FileName)
-	if err != nil {
-		return 0, errors.New("fail opening data file: " + err.Error())
-	}
-	defer dataFile.Close()
-
-	//	read config file line by line
-	var dataRow []byte
-	var rowValue map[string]string
-
-	rowValue = make(map[string]string)
+	rowValue := NewRow()
 
-	rowsProcessed = 0
-	dataFileReader := bufio.NewReader(dataFile)
+	input := f.inputFileSet()
 
-	for {
-		dataRow, _, err = dataFileReader.ReadLine()
-		if err != nil {
-			break
-		}
-		//	if file have a reader, ignores it
-		if rowsProcessed == 0 && f.Header {
-			continue
-		}
+	rowsProcessed, err = input.readLines(ctx, func(source string, lineNumber int64, dataRow []byte) error {
 
 		//	extract fields from input line
+		line := string(dataRow)
+		rowValue.Reset()
+
 		for _, field := range f.FieldList {
-			rowValue[field.Name] = string(dataRow[field.StartPosition-1 : field.EndPosition])
+			if int(field.EndPosition) > len(dataRow) {
+				return rowError(f.rejecter, source, lineNumber, dataRow, &FieldValidationError{
+					Field:  field.Name,
+					Reason: "line too short: " + strconv.Itoa(len(dataRow)) + " characters",
+				})
+			}
+
+			err := setRowValue(rowValue, field, line[field.StartPosition-1:field.EndPosition])
+			if err != nil {
+				return rowError(f.rejecter, source, lineNumber, dataRow, err)
+			}
 		}
 
+		rowValue.Source = source
+		rowValue.LineNumber = lineNumber
+		rowValue.Record = line
+
 		//	if available, invoke the next step in the pipeline
 		if nextStep != nil {
-			nextStep.ProcessRow(rowValue)
+			_, err := nextStep.ProcessRow(rowValue)
+			if err != nil {
+				return rowError(f.rejecter, source, lineNumber, dataRow, err)
+			}
 		}
 
-		rowsProcessed++
-	}
+		return nil
+	})
+	f.processedFiles = input.processedFiles
+
+	return rowsProcessed, err
+}
 
-	return rowsProcessed, nil
+//	SetRowRejecter set the rejecter for the rows the pipeline fails to process
+func (f *fixedPositionInputFile) SetRowRejecter(rejecter RowRejecter) {
+	f.rejecter = rejecter
+}
+
+//	SetCheckpointer set the checkpointer that saves the position of the processed rows
+func (f *fixedPositionInputFile) SetCheckpointer(checkpointer InputCheckpointer) {
+	f.checkpointer = checkpointer
+}
+
+//	ProcessedFiles get the list of files processed by the last import, with their row counts
+func (f *fixedPositionInputFile) ProcessedFiles() []ProcessedFile {
+	return f.processedFiles
+}
+
+//	inputFileSet get the set of input files
+func (f *fixedPositionInputFile) inputFileSet() *inputFiles {
+
+	return &inputFiles{
+		FileName:     f.FileName,
+		FileNameList: f.FileNameList,
+		Compression:  f.Compression,
+		Header:       f.Header,
+		Trailer:      f.Trailer,
+		checkpointer: f.checkpointer,
+	}
 }
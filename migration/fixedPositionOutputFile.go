@@ -38,19 +38,9 @@ func (s *fixedPositionOutputFile) GetNextStep() DataPipelineStep {
 }
 
 //	ProcessRow generate a trace of the data row
-func (s *fixedPositionOutputFile) ProcessRow(row map[string]string) (rowProcessed bool, err error) {
+func (s *fixedPositionOutputFile) ProcessRow(row *Row) (rowProcessed bool, err error) {
 
-	var i uint
-
-	for fieldName, fieldValue := range row {
-		if i > 0 {
-			fmt.Fprintf(os.Stdout, "; ")
-		}
-		fmt.Fprintf(os.Stdout, "%s = '%s'", fieldName, fieldValue)
-
-		i++
-	}
-	fmt.Fprintf(os.Stdout, "\n")
+	fmt.Fprintf(os.Stdout, "%s\n", row)
 
 	//	if available, invoke the next step in the pipeline
 	if s.NextStep != nil {
@@ -2,4 +2,4 @@ module github.com/aldebap/go-dmig/migration
 
 go 1.17
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require gopkg.in/yaml.v3 v3.0.1
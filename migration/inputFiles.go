@@ -0,0 +1,608 @@
+///////////////////////////////////////////////////////////////////////////////
+//	inputFiles.go  -  Oct-19-2026  -  aldebap
+//
+//	Open input files, including file lists, glob patterns and members of ZIP and TAR archives
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//	separator between the archive file name and the member name (or pattern)
+const archiveMemberSeparator = "!/"
+
+//	constants for archive types
+const (
+	ZIP_ARCHIVE = 1
+	TAR_ARCHIVE = 2
+)
+
+var (
+	archive_extension = map[string]uint8{
+		".zip":     ZIP_ARCHIVE,
+		".tar":     TAR_ARCHIVE,
+		".tar.gz":  TAR_ARCHIVE,
+		".tgz":     TAR_ARCHIVE,
+		".tar.bz2": TAR_ARCHIVE,
+		".tbz2":    TAR_ARCHIVE,
+	}
+
+	//	compression of the TAR archives with a short extension, as the extension isn't a compression extension
+	archive_compression = map[string]string{
+		".tgz":  "gzip",
+		".tbz2": "bzip2",
+	}
+)
+
+//	names of the row metadata fields, the source file name and line number
+const (
+	ROW_SOURCE_FILE = "_source_file"
+	ROW_LINE_NUMBER = "_line_number"
+)
+
+//	attributes for a processed input file
+type ProcessedFile struct {
+	Name string
+	Rows int64
+}
+
+//	attributes for the set of files of an input
+type inputFiles struct {
+	FileName     string
+	FileNameList []string
+	Compression  string
+	Header       bool
+	Trailer      bool
+
+	processedFiles []ProcessedFile
+	checkpointer   InputCheckpointer
+}
+
+//	validate validate input file names, patterns and compression
+func (i *inputFiles) validate() error {
+
+	for _, fileName := range i.fileNames() {
+		archiveName, _, _ := splitArchivePath(fileName)
+
+		_, err := filepath.Match(archiveName, "")
+		if err != nil {
+			return errors.New("Invalid file name pattern: " + fileName)
+		}
+
+		err = validateInputFile(fileName, i.Compression)
+		if err != nil {
+			return err
+		}
+	}
+
+	return validateCompression(i.Compression)
+}
+
+//	fileNames get the file name and file name list as a single list
+func (i *inputFiles) fileNames() []string {
+
+	var fileNameList []string
+
+	if len(i.FileName) > 0 {
+		fileNameList = append(fileNameList, i.FileName)
+	}
+
+	return append(fileNameList, i.FileNameList...)
+}
+
+//	expandFileNames expand glob patterns into the sorted list of matching files
+func (i *inputFiles) expandFileNames() ([]string, error) {
+
+	var fileNameList []string
+
+	for _, fileName := range i.fileNames() {
+		archiveName, memberPattern, isArchive := splitArchivePath(fileName)
+
+		if !strings.ContainsAny(archiveName, "*?[") {
+			fileNameList = append(fileNameList, fileName)
+			continue
+		}
+
+		matches, err := filepath.Glob(archiveName)
+		if err != nil {
+			return nil, errors.New("Invalid file name pattern: " + fileName)
+		}
+		if len(matches) == 0 {
+			return nil, errors.New("fail opening data file: no file matching " + archiveName)
+		}
+		sort.Strings(matches)
+
+		for _, match := range matches {
+			if isArchive {
+				match += archiveMemberSeparator + memberPattern
+			}
+			fileNameList = append(fileNameList, match)
+		}
+	}
+
+	if len(fileNameList) == 0 {
+		return nil, errors.New("fail opening data file: missing data file name")
+	}
+
+	return fileNameList, nil
+}
+
+//	readLines read every input file line by line, skipping each file's header and trailer,
+//	and process each data line with its source name and line number; with a checkpointer, reading starts
+//	after the last row processed by the previous run, and the position of each processed row is reported;
+//	reading stops when the context is cancelled
+func (i *inputFiles) readLines(ctx context.Context, processLine func(source string, lineNumber int64, line []byte) error) (rowsProcessed int64, err error) {
+
+	i.processedFiles = nil
+
+	fileNameList, err := i.expandFileNames()
+	if err != nil {
+		return 0, err
+	}
+
+	var resume *InputPosition
+	if i.checkpointer != nil {
+		resume = i.checkpointer.ResumePosition()
+	}
+	if resume != nil {
+		rowsProcessed = resume.Rows
+	}
+
+	fileIndex := 0
+
+	for _, fileName := range fileNameList {
+		err = forEachInputFile(fileName, i.Compression, func(name string, dataFile io.Reader) error {
+
+			index := fileIndex
+			fileIndex++
+
+			//	files processed before the checkpoint are skipped
+			if resume != nil && index < resume.FileIndex {
+				return nil
+			}
+
+			processedFile := ProcessedFile{Name: name}
+			defer func() {
+				i.processedFiles = append(i.processedFiles, processedFile)
+			}()
+
+			//	read data file line by line, holding the last line as it may be the trailer
+			var pendingLine []byte
+			var pendingNumber int64
+			var pendingOffset int64
+			var lineNumber int64
+			var offset int64
+
+			if resume != nil && index == resume.FileIndex {
+				if name != resume.FileName {
+					return errors.New("Checkpoint doesn't match input file: expected " + resume.FileName + ", found " + name)
+				}
+
+				err := skipInput(dataFile, resume.Offset)
+				if err != nil {
+					return errors.New("fail resuming " + name + ": " + err.Error())
+				}
+
+				lineNumber = resume.LineNumber
+				offset = resume.Offset
+				processedFile.Rows = resume.FileRows
+			}
+
+			dataFileReader := bufio.NewReader(dataFile)
+
+			for {
+				//	stop reading when the job is interrupted
+				if ctx.Err() != nil {
+					return jobInterrupted(ctx)
+				}
+
+				dataRow, lineSize, err := readLine(dataFileReader)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return errors.New("fail reading " + name + ": " + err.Error())
+				}
+				lineNumber++
+				offset += lineSize
+
+				//	if file have a header, ignores it
+				if lineNumber == 1 && i.Header {
+					continue
+				}
+
+				if pendingLine != nil {
+					err = processLine(name, pendingNumber, pendingLine)
+					if err != nil {
+						return err
+					}
+					processedFile.Rows++
+					rowsProcessed++
+
+					err = i.checkpoint(InputPosition{FileIndex: index, FileName: name, LineNumber: pendingNumber,
+						Offset: pendingOffset, FileRows: processedFile.Rows, Rows: rowsProcessed})
+					if err != nil {
+						return err
+					}
+				}
+
+				pendingLine = dataRow
+				pendingNumber = lineNumber
+				pendingOffset = offset
+			}
+
+			//	if file have a trailer, ignores it
+			if pendingLine != nil && !i.Trailer {
+				err := processLine(name, pendingNumber, pendingLine)
+				if err != nil {
+					return err
+				}
+				processedFile.Rows++
+				rowsProcessed++
+
+				err = i.checkpoint(InputPosition{FileIndex: index, FileName: name, LineNumber: pendingNumber,
+					Offset: pendingOffset, FileRows: processedFile.Rows, Rows: rowsProcessed})
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return rowsProcessed, err
+		}
+	}
+
+	return rowsProcessed, nil
+}
+
+//	jobInterrupted get the error for a job interrupted by the context cancellation
+func jobInterrupted(ctx context.Context) error {
+	return fmt.Errorf("Job interrupted: %w", ctx.Err())
+}
+
+//	checkpoint report the position of a processed row to the checkpointer
+func (i *inputFiles) checkpoint(position InputPosition) error {
+
+	if i.checkpointer == nil {
+		return nil
+	}
+
+	return i.checkpointer.Checkpoint(position)
+}
+
+//	readLine read a line without its line end ("\n" or "\r\n"), with the number of bytes it took in the file
+func readLine(reader *bufio.Reader) (line []byte, lineSize int64, err error) {
+
+	line, err = reader.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	lineSize = int64(len(line))
+
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	if lineSize > int64(len(line)) {
+		line = bytes.TrimSuffix(line, []byte("\r"))
+	}
+
+	return line, lineSize, nil
+}
+
+//	skipInput skip the bytes of an input file that were read before a checkpoint
+func skipInput(dataFile io.Reader, offset int64) error {
+
+	if offset == 0 {
+		return nil
+	}
+
+	//	plain files are positioned directly; compressed files and archive members are read up to the offset
+	if seeker, ok := dataFile.(io.Seeker); ok {
+		_, err := seeker.Seek(offset, io.SeekStart)
+		return err
+	}
+
+	skipped, err := io.CopyN(io.Discard, dataFile, offset)
+	if err == io.EOF {
+		return errors.New("input shorter than the checkpoint: " + strconv.FormatInt(skipped, 10) + " bytes")
+	}
+
+	return err
+}
+
+//	setRowValue set a field value read from an input file, converted to the field's type; the field's null values
+//	and blank values of the types other than string are null
+func setRowValue(row *Row, field DataField, value string) error {
+
+	if isNullValue(field, value) {
+		row.Set(field.Name, nil)
+		return nil
+	}
+
+	typedValue, err := parseFieldValue(data_field_type[field.Type], field.Format, value)
+	if err != nil {
+		return &FieldValidationError{Field: field.Name, Value: strings.TrimSpace(value), Reason: err.Error()}
+	}
+	row.Set(field.Name, typedValue)
+
+	return nil
+}
+
+//	parseFieldValue convert a field value text to the field's type; blank values of the types other than string
+//	are null, and dates and timestamps are read with the field's format
+func parseFieldValue(fieldType uint8, format string, value string) (interface{}, error) {
+
+	if fieldType == STRING || fieldType == 0 {
+		return value, nil
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case INTEGER:
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errors.New("not an integer: " + value)
+		}
+		return intValue, nil
+
+	case DECIMAL:
+		decimal, err := ParseDecimal(value)
+		if err != nil {
+			return nil, errors.New("not a decimal: " + value)
+		}
+		return decimal, nil
+
+	case DATE, TIMESTAMP:
+		date, err := time.Parse(fieldLayout(fieldType, format), value)
+		if err != nil {
+			return nil, errors.New("not a " + fieldTypeName(fieldType) + ": " + value)
+		}
+		return date, nil
+
+	case BOOLEAN:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New("not a boolean: " + value)
+		}
+		return boolValue, nil
+	}
+
+	return value, nil
+}
+
+//	fieldLayout get the Go time layout for a date or timestamp field's format
+func fieldLayout(fieldType uint8, format string) string {
+
+	if len(format) == 0 {
+		format = defaultDateFormat
+		if fieldType == TIMESTAMP {
+			format = defaultTimestampFormat
+		}
+	}
+
+	return dateFormatReplacer.Replace(format)
+}
+
+//	fieldTypeName get the configuration name of a field type
+func fieldTypeName(fieldType uint8) string {
+
+	for name, value := range data_field_type {
+		if value == fieldType {
+			return name
+		}
+	}
+
+	return ""
+}
+
+//	isNullValue check if an input value is one of the field's null values, ignoring padding spaces
+func isNullValue(field DataField, value string) bool {
+
+	if len(field.NullValues) == 0 {
+		return false
+	}
+
+	value = strings.TrimSpace(value)
+
+	for _, nullValue := range field.NullValues {
+		if value == strings.TrimSpace(nullValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//	splitArchivePath split a file name like "batch.zip!/customers.txt" into archive and member pattern
+func splitArchivePath(fileName string) (archiveName string, memberPattern string, isArchive bool) {
+
+	i := strings.Index(fileName, archiveMemberSeparator)
+	if i < 0 {
+		return fileName, "", false
+	}
+
+	return fileName[:i], fileName[i+len(archiveMemberSeparator):], true
+}
+
+//	archiveType get the archive type from the archive file extension
+func archiveType(archiveName string) (uint8, error) {
+
+	lowerName := strings.ToLower(archiveName)
+
+	for extension, archiveType := range archive_extension {
+		if strings.HasSuffix(lowerName, extension) {
+			return archiveType, nil
+		}
+	}
+
+	return 0, errors.New("Unsupported archive type: " + archiveName)
+}
+
+//	validateInputFile validate the input file name and compression attribute
+func validateInputFile(fileName string, compression string) error {
+
+	archiveName, memberPattern, isArchive := splitArchivePath(fileName)
+
+	//	archives can only be read through their members
+	_, err := archiveType(archiveName)
+	if !isArchive {
+		if err == nil {
+			return errors.New("Missing archive member for input file: " + fileName)
+		}
+		return validateInputCompression(fileName, compression)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = path.Match(memberPattern, "")
+	if err != nil || len(memberPattern) == 0 {
+		return errors.New("Invalid archive member pattern: " + memberPattern)
+	}
+
+	return validateInputCompression(archiveName, compression)
+}
+
+//	forEachInputFile open the input file, or each archive member it points to, and process its content
+func forEachInputFile(fileName string, compression string, process func(name string, reader io.Reader) error) error {
+
+	archiveName, memberPattern, isArchive := splitArchivePath(fileName)
+	if !isArchive {
+		dataFile, err := openInputFile(fileName, compression)
+		if err != nil {
+			return errors.New("fail opening data file: " + err.Error())
+		}
+		defer dataFile.Close()
+
+		return process(fileName, dataFile)
+	}
+
+	archive, err := archiveType(archiveName)
+	if err != nil {
+		return err
+	}
+
+	if archive == ZIP_ARCHIVE {
+		return forEachZipMember(archiveName, memberPattern, process)
+	}
+
+	return forEachTarMember(archiveName, compression, memberPattern, process)
+}
+
+//	forEachZipMember process the members of a ZIP archive matching the pattern, in name order
+func forEachZipMember(archiveName string, memberPattern string, process func(name string, reader io.Reader) error) error {
+
+	zipReader, err := zip.OpenReader(archiveName)
+	if err != nil {
+		return errors.New("fail opening data file: " + err.Error())
+	}
+	defer zipReader.Close()
+
+	var memberList []*zip.File
+
+	for _, member := range zipReader.File {
+		if member.FileInfo().IsDir() {
+			continue
+		}
+
+		matched, _ := path.Match(memberPattern, member.Name)
+		if matched {
+			memberList = append(memberList, member)
+		}
+	}
+
+	if len(memberList) == 0 {
+		return errors.New("fail opening data file: no archive member matching " + memberPattern + " in " + archiveName)
+	}
+
+	sort.Slice(memberList, func(i, j int) bool {
+		return memberList[i].Name < memberList[j].Name
+	})
+
+	for _, member := range memberList {
+		memberReader, err := member.Open()
+		if err != nil {
+			return errors.New("fail opening data file: " + err.Error())
+		}
+
+		err = process(archiveName+archiveMemberSeparator+member.Name, memberReader)
+		memberReader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//	forEachTarMember process the members of a TAR archive matching the pattern, in archive order
+func forEachTarMember(archiveName string, compression string, memberPattern string,
+	process func(name string, reader io.Reader) error) error {
+
+	if len(compression) == 0 {
+		compression = archive_compression[strings.ToLower(filepath.Ext(archiveName))]
+	}
+
+	archiveFile, err := openInputFile(archiveName, compression)
+	if err != nil {
+		return errors.New("fail opening data file: " + err.Error())
+	}
+	defer archiveFile.Close()
+
+	tarReader := tar.NewReader(archiveFile)
+	membersFound := 0
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.New("fail reading archive " + archiveName + ": " + err.Error())
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		matched, _ := path.Match(memberPattern, header.Name)
+		if !matched {
+			continue
+		}
+		membersFound++
+
+		err = process(archiveName+archiveMemberSeparator+header.Name, tarReader)
+		if err != nil {
+			return err
+		}
+	}
+
+	if membersFound == 0 {
+		return errors.New("fail opening data file: no archive member matching " + memberPattern + " in " + archiveName)
+	}
+
+	return nil
+}
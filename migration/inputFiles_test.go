@@ -0,0 +1,350 @@
+///////////////////////////////////////////////////////////////////////////////
+//	inputFiles_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for input files, including file lists, glob patterns and members of ZIP and TAR archives
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+)
+
+//	test archive members
+var testArchiveMembers = []struct {
+	name    string
+	content string
+}{
+	{name: "part_02.txt", content: "HEADER\n3,LINE#3\n"},
+	{name: "part_01.txt", content: "HEADER\n1,LINE#1\n2,LINE#2\n"},
+	{name: "readme.md", content: "not a data file\n"},
+}
+
+//	createTestZip create a ZIP archive with the test members
+func createTestZip(fileName string) error {
+
+	archiveFile, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	zipWriter := zip.NewWriter(archiveFile)
+
+	for _, member := range testArchiveMembers {
+		memberWriter, err := zipWriter.Create(member.name)
+		if err != nil {
+			return err
+		}
+		memberWriter.Write([]byte(member.content))
+	}
+
+	return zipWriter.Close()
+}
+
+//	createTestTarGz create a gzip compressed TAR archive with the test members
+func createTestTarGz(fileName string) error {
+
+	archiveFile, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	gzipWriter := gzip.NewWriter(archiveFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, member := range testArchiveMembers {
+		err = tarWriter.WriteHeader(&tar.Header{Name: member.name, Mode: 0644, Size: int64(len(member.content))})
+		if err != nil {
+			return err
+		}
+		tarWriter.Write([]byte(member.content))
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+//	Test_InputFiles_ValidateFormat test cases for validation of input file names
+func Test_InputFiles_ValidateFormat(t *testing.T) {
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		input    string
+		output   string
+	}{
+		{scenario: "plain file", input: "data.txt", output: ""},
+		{scenario: "zip member", input: "batch.zip!/customers.txt", output: ""},
+		{scenario: "tar member pattern", input: "batch.tar.gz!/part_*.txt", output: ""},
+		{scenario: "unsupported archive", input: "batch.rar!/customers.txt", output: "Unsupported archive type: batch.rar"},
+		{scenario: "missing member", input: "batch.zip!/", output: "Invalid archive member pattern: "},
+		{scenario: "malformed member pattern", input: "batch.zip!/part_[.txt", output: "Invalid archive member pattern: part_[.txt"},
+		{scenario: "archive without member", input: "batch.tgz", output: "Missing archive member for input file: batch.tgz"},
+		{scenario: "unsupported archive compression", input: "batch.zip.zst!/customers.txt", output: "Unsupported archive type: batch.zip.zst"},
+	}
+
+	t.Run(">>> validation of input file names", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			got := ""
+			want := test.output
+
+			err := validateInputFile(test.input, "")
+			if err != nil {
+				got = err.Error()
+			}
+
+			if want != got {
+				t.Errorf("fail in validateInputFile(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_InputFiles_ImportData test cases for importing data from archive members
+func Test_InputFiles_ImportData(t *testing.T) {
+
+	const testZipName = "testData.zip"
+	const testTarName = "testData.tar.gz"
+
+	err := createTestZip(testZipName)
+	if err != nil {
+		t.Errorf("unexpected error creating test archive: %s", err)
+	}
+	defer os.Remove(testZipName)
+
+	err = createTestTarGz(testTarName)
+	if err != nil {
+		t.Errorf("unexpected error creating test archive: %s", err)
+	}
+	defer os.Remove(testTarName)
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		input    string
+		rows     int64
+		output   string
+	}{
+		{scenario: "single zip member", input: testZipName + "!/part_01.txt", rows: 2},
+		{scenario: "zip member pattern", input: testZipName + "!/part_*.txt", rows: 3},
+		{scenario: "tar member pattern", input: testTarName + "!/part_*.txt", rows: 3},
+		{scenario: "no matching member", input: testZipName + "!/*.csv", rows: 0,
+			output: "fail opening data file: no archive member matching *.csv in " + testZipName},
+	}
+
+	t.Run(">>> validation of archive members importing", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			testDataSource := NewCSVInputFile(JobInput{
+				FileName:       test.input,
+				FieldSeparator: ",",
+				Header:         true,
+				FieldList: []DataField{
+					{Name: "test_1", Type: "string"},
+					{Name: "test_2", Type: "string"},
+				},
+			})
+
+			gotErr := ""
+			rows, err := testDataSource.ImportData(context.Background(), nil)
+			if err != nil {
+				gotErr = err.Error()
+			}
+
+			if test.output != gotErr {
+				t.Errorf("fail in ImportData(): expected: %s result: %s", test.output, gotErr)
+			}
+			if test.rows != rows {
+				t.Errorf("fail in ImportData(): expected: %d rows result: %d rows", test.rows, rows)
+			}
+		}
+	})
+}
+
+//	test step that keeps a copy of every row processed
+type rowCollector struct {
+	rows []*Row
+
+	NextStep DataPipelineStep
+}
+
+func (s *rowCollector) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+func (s *rowCollector) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+func (s *rowCollector) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	s.rows = append(s.rows, row.Copy())
+
+	return true, nil
+}
+
+//	newTestRow create a row with string values, setting the fields in name order
+func newTestRow(values map[string]string) *Row {
+
+	var fieldList []string
+	for name := range values {
+		fieldList = append(fieldList, name)
+	}
+	sort.Strings(fieldList)
+
+	row := NewRow()
+	for _, name := range fieldList {
+		row.Set(name, values[name])
+	}
+
+	return row
+}
+
+//	newInputTestRow create a row the way input files do, converting the values to the fields types
+func newInputTestRow(values map[string]string, fieldList []DataField) *Row {
+
+	row := NewRow()
+	for _, field := range fieldList {
+		setRowValue(row, field, values[field.Name])
+	}
+
+	return row
+}
+
+//	Test_InputFiles_MultipleFiles test cases for importing data from glob patterns and file lists
+func Test_InputFiles_MultipleFiles(t *testing.T) {
+
+	testFiles := map[string]string{
+		"testData_02.txt": "HEADER\n3,LINE#3\nTRAILER\n",
+		"testData_01.txt": "HEADER\n1,LINE#1\n2,LINE#2\nTRAILER\n",
+		"testExtra.txt":   "HEADER\n4,LINE#4\nTRAILER\n",
+	}
+
+	for fileName, content := range testFiles {
+		err := os.WriteFile(fileName, []byte(content), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(fileName)
+	}
+
+	t.Run(">>> validation of glob pattern and file list importing", func(t *testing.T) {
+
+		collector := &rowCollector{}
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       "testData_*.txt",
+			FileNameList:   []string{"testExtra.txt"},
+			FieldSeparator: ",",
+			Header:         true,
+			Trailer:        true,
+			FieldList: []DataField{
+				{Name: "test_1", Type: "integer"},
+				{Name: "test_2", Type: "string"},
+			},
+		})
+
+		rows, err := testDataSource.ImportData(context.Background(), collector)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+		if rows != 4 {
+			t.Errorf("fail in ImportData(): expected: %d rows result: %d rows", 4, rows)
+		}
+
+		//	rows are processed in file order and tagged with source and line number
+		var got []string
+		for _, row := range collector.rows {
+			got = append(got, row.GetString("test_1")+"@"+row.GetString(ROW_SOURCE_FILE)+":"+row.GetString(ROW_LINE_NUMBER))
+		}
+		want := []string{"1@testData_01.txt:2", "2@testData_01.txt:3", "3@testData_02.txt:2", "4@testExtra.txt:2"}
+
+		if fmt.Sprint(want) != fmt.Sprint(got) {
+			t.Errorf("fail in ImportData(): expected: %v result: %v", want, got)
+		}
+
+		//	per file counts
+		gotFiles := fmt.Sprint(testDataSource.ProcessedFiles())
+		wantFiles := fmt.Sprint([]ProcessedFile{{Name: "testData_01.txt", Rows: 2}, {Name: "testData_02.txt", Rows: 1},
+			{Name: "testExtra.txt", Rows: 1}})
+
+		if wantFiles != gotFiles {
+			t.Errorf("fail in ProcessedFiles(): expected: %s result: %s", wantFiles, gotFiles)
+		}
+	})
+
+	t.Run(">>> validation of import interrupted", func(t *testing.T) {
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		//	the job is interrupted while the second row is processed
+		var idList []string
+		collector := &rowCopyStep{
+			process: func(row *Row) error {
+				idList = append(idList, row.GetString("test_1"))
+				if len(idList) == 2 {
+					cancel()
+				}
+				return nil
+			},
+		}
+
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       "testData_*.txt",
+			FileNameList:   []string{"testExtra.txt"},
+			FieldSeparator: ",",
+			Header:         true,
+			Trailer:        true,
+			FieldList: []DataField{
+				{Name: "test_1", Type: "integer"},
+				{Name: "test_2", Type: "string"},
+			},
+		})
+
+		rows, err := testDataSource.ImportData(ctx, collector)
+		if err == nil || err.Error() != "Job interrupted: context canceled" || !errors.Is(err, context.Canceled) {
+			t.Errorf("fail in ImportData(): expected interrupted job, result: %v", err)
+		}
+		if rows != 2 || fmt.Sprint(idList) != "[1 2]" {
+			t.Errorf("fail in ImportData(): expected 2 rows, result: %d rows %v", rows, idList)
+		}
+	})
+
+	t.Run(">>> validation of glob pattern without matching files", func(t *testing.T) {
+
+		testDataSource := NewFixedPositionInputFile(JobInput{FileName: "xpto_*.txt"})
+
+		got := ""
+		want := "fail opening data file: no file matching xpto_*.txt"
+
+		_, err := testDataSource.ImportData(context.Background(), nil)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in ImportData(): expected: %s result: %s", want, got)
+		}
+	})
+}
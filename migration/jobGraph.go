@@ -0,0 +1,363 @@
+///////////////////////////////////////////////////////////////////////////////
+//	jobGraph.go  -  Oct-19-2026  -  aldebap
+//
+//	Dependency graph used to run the migration jobs
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+//	constants for job states
+const (
+	JOB_PENDING     = 1
+	JOB_RUNNING     = 2
+	JOB_FINISHED    = 3
+	JOB_FAILED      = 4
+	JOB_SKIPPED     = 5
+	JOB_INTERRUPTED = 6
+)
+
+var (
+	job_state_name = map[uint8]string{
+		JOB_PENDING:     "pending",
+		JOB_RUNNING:     "running",
+		JOB_FINISHED:    "finished",
+		JOB_FAILED:      "failed",
+		JOB_SKIPPED:     "skipped",
+		JOB_INTERRUPTED: "interrupted",
+	}
+)
+
+//	attributes of a job in the dependency graph
+type jobNode struct {
+	job          *MigrationJob
+	dependencies []int
+	dependents   []int
+	state        uint8
+	err          error
+	duration     time.Duration
+}
+
+//	attributes of the migration jobs dependency graph
+type jobGraph struct {
+	nodeList []*jobNode
+}
+
+//	function that performs a job, writing its messages to the given writer
+type jobRunner func(ctx context.Context, job *MigrationJob, messages io.Writer) error
+
+//	writer for the messages of a job running in parallel with other jobs: each line is written as soon as it's
+//	complete, prefixed by the job name, and the shared mutex keeps the lines of different jobs from mixing
+type jobMessageWriter struct {
+	mutex   *sync.Mutex
+	out     io.Writer
+	prefix  string
+	pending []byte
+}
+
+func (w *jobMessageWriter) Write(data []byte) (int, error) {
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.pending = append(w.pending, data...)
+
+	end := bytes.LastIndexByte(w.pending, '\n')
+	if end >= 0 {
+		w.writeLines(w.pending[:end+1])
+		w.pending = append([]byte{}, w.pending[end+1:]...)
+	}
+
+	return len(data), nil
+}
+
+//	flush write the last line of the job's messages, when it isn't complete
+func (w *jobMessageWriter) flush() {
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.pending) > 0 {
+		w.writeLines(append(w.pending, '\n'))
+		w.pending = nil
+	}
+}
+
+//	writeLines write complete lines with the job name prefix; the mutex must be locked
+func (w *jobMessageWriter) writeLines(lines []byte) {
+
+	var prefixed bytes.Buffer
+
+	//	blank lines are left without prefix
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 1 {
+			prefixed.WriteString(w.prefix)
+		}
+		prefixed.Write(line)
+	}
+
+	w.out.Write(prefixed.Bytes())
+}
+
+//	attributes of a job that finished running
+type jobResult struct {
+	index    int
+	err      error
+	duration time.Duration
+}
+
+//	newJobGraph build the dependency graph of the jobs; dependencies must name a single job, and must not be circular
+func newJobGraph(jobList []MigrationJob) (*jobGraph, error) {
+
+	graph := &jobGraph{}
+	jobIndex := make(map[string][]int)
+
+	for i := range jobList {
+		graph.nodeList = append(graph.nodeList, &jobNode{
+			job:   &jobList[i],
+			state: JOB_PENDING,
+		})
+		jobIndex[jobList[i].Name] = append(jobIndex[jobList[i].Name], i)
+	}
+
+	for i, node := range graph.nodeList {
+		for _, name := range node.job.DependsOn {
+			indexList := jobIndex[name]
+			if len(indexList) == 0 {
+				return nil, errors.New("Invalid job dependency: " + node.job.Name + " depends on unknown job " + name)
+			}
+			if len(indexList) > 1 {
+				return nil, errors.New("Invalid job dependency: " + node.job.Name + " depends on duplicated job name " + name)
+			}
+			if indexList[0] == i {
+				return nil, errors.New("Invalid job dependency: " + node.job.Name + " depends on itself")
+			}
+
+			node.dependencies = append(node.dependencies, indexList[0])
+			graph.nodeList[indexList[0]].dependents = append(graph.nodeList[indexList[0]].dependents, i)
+		}
+	}
+
+	cycle := graph.findCycle()
+	if len(cycle) > 0 {
+		return nil, errors.New("Circular job dependency: " + cycle)
+	}
+
+	return graph, nil
+}
+
+//	findCycle find the jobs that can never run because their dependencies are circular
+func (g *jobGraph) findCycle() string {
+
+	pending := make([]int, len(g.nodeList))
+	var ready []int
+
+	for i, node := range g.nodeList {
+		pending[i] = len(node.dependencies)
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+
+		for _, dependent := range g.nodeList[i].dependents {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	var cycle string
+
+	for i, node := range g.nodeList {
+		if pending[i] > 0 {
+			if len(cycle) > 0 {
+				cycle += ", "
+			}
+			cycle += node.job.Name
+		}
+	}
+
+	return cycle
+}
+
+//	run run the jobs, starting each one as soon as all its dependencies finish, with up to maxParallel jobs at a time;
+//	the jobs depending on a failed job are skipped. When jobs run in parallel, each line of the jobs' messages is
+//	written as it comes, prefixed by the job name. Once the context is cancelled, no more jobs are started, and the
+//	running jobs are waited for
+func (g *jobGraph) run(ctx context.Context, runner jobRunner, maxParallel int, messages io.Writer) {
+
+	pending := make([]int, len(g.nodeList))
+	var ready []int
+
+	for i, node := range g.nodeList {
+		pending[i] = len(node.dependencies)
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	results := make(chan jobResult)
+	running := 0
+
+	var messagesMutex sync.Mutex
+
+	for len(ready) > 0 || running > 0 {
+
+		//	after an interruption, the jobs not started are skipped
+		if ctx.Err() != nil && len(ready) > 0 {
+			for _, index := range ready {
+				g.nodeList[index].state = JOB_SKIPPED
+				g.nodeList[index].err = errors.New("migration interrupted")
+				g.skipDependents(index)
+			}
+			ready = nil
+
+			if running == 0 {
+				break
+			}
+		}
+
+		//	start the ready jobs in the configuration order
+		for len(ready) > 0 && running < maxParallel {
+			node := g.nodeList[ready[0]]
+			node.state = JOB_RUNNING
+
+			var jobMessages *jobMessageWriter
+			if maxParallel > 1 {
+				jobMessages = &jobMessageWriter{mutex: &messagesMutex, out: messages, prefix: "[" + node.job.Name + "] "}
+			}
+
+			go func(index int, job *MigrationJob, jobMessages *jobMessageWriter) {
+				start := time.Now()
+
+				var err error
+				if jobMessages != nil {
+					err = runner(ctx, job, jobMessages)
+					jobMessages.flush()
+				} else {
+					err = runner(ctx, job, messages)
+				}
+				results <- jobResult{index: index, err: err, duration: time.Since(start)}
+			}(ready[0], node.job, jobMessages)
+
+			ready = ready[1:]
+			running++
+		}
+
+		result := <-results
+		running--
+
+		node := g.nodeList[result.index]
+		node.err = result.err
+		node.duration = result.duration
+
+		if node.err != nil {
+			node.state = JOB_FAILED
+			if ctx.Err() != nil && errors.Is(node.err, ctx.Err()) {
+				node.state = JOB_INTERRUPTED
+			}
+			g.skipDependents(result.index)
+			continue
+		}
+		node.state = JOB_FINISHED
+
+		for _, dependent := range node.dependents {
+			pending[dependent]--
+			if pending[dependent] == 0 && g.nodeList[dependent].state == JOB_PENDING {
+				ready = append(ready, dependent)
+			}
+		}
+		sort.Ints(ready)
+	}
+}
+
+//	skipDependents skip the jobs that depend, directly or not, on a job that didn't finish
+func (g *jobGraph) skipDependents(index int) {
+
+	for _, dependent := range g.nodeList[index].dependents {
+		node := g.nodeList[dependent]
+		if node.state != JOB_PENDING {
+			continue
+		}
+
+		node.state = JOB_SKIPPED
+		node.err = errors.New("dependency " + g.nodeList[index].job.Name + " " + job_state_name[g.nodeList[index].state])
+		g.skipDependents(dependent)
+	}
+}
+
+//	err get the error of the first failed (or interrupted) job
+func (g *jobGraph) err() error {
+
+	var firstErr error
+	failed := 0
+
+	for _, node := range g.nodeList {
+		if node.state == JOB_FAILED || node.state == JOB_INTERRUPTED {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("fail in job %s: %w", node.job.Name, node.err)
+			}
+			failed++
+		}
+	}
+
+	if failed > 1 {
+		return fmt.Errorf("%w (and %d more failed jobs)", firstErr, failed-1)
+	}
+
+	return firstErr
+}
+
+//	writeStatus write a table with the final state of each job
+func (g *jobGraph) writeStatus(messages io.Writer) {
+
+	fmt.Fprintf(messages, "\n>>> Migration Jobs Status\n")
+
+	table := tabwriter.NewWriter(messages, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(table, "Job\tStatus\tDuration\tDetail\n")
+	for _, node := range g.nodeList {
+		duration := "-"
+		if node.state == JOB_FINISHED || node.state == JOB_FAILED || node.state == JOB_INTERRUPTED {
+			duration = node.duration.Round(time.Millisecond).String()
+		}
+
+		detail := ""
+		if node.err != nil {
+			detail = node.err.Error()
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", node.job.Name, job_state_name[node.state], duration, detail)
+	}
+	table.Flush()
+}
+
+//	validateMaxParallelJobs validate the maximum number of jobs running at the same time; zero means one job at a time
+func validateMaxParallelJobs(maxParallel int) (int, error) {
+
+	if maxParallel < 0 {
+		return 0, errors.New("Invalid max parallel jobs: " + strconv.Itoa(maxParallel))
+	}
+	if maxParallel == 0 {
+		return 1, nil
+	}
+
+	return maxParallel, nil
+}
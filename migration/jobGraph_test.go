@@ -0,0 +1,272 @@
+///////////////////////////////////////////////////////////////////////////////
+//	jobGraph_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the migration jobs dependency graph
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//	Test_JobGraph_Validation test cases for the jobs dependencies validation
+func Test_JobGraph_Validation(t *testing.T) {
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		jobList  []MigrationJob
+		err      string
+	}{
+		{scenario: "independent jobs", jobList: []MigrationJob{{Name: "a"}, {Name: "b"}, {Name: "a"}}},
+		{scenario: "unknown dependency", jobList: []MigrationJob{{Name: "a", DependsOn: []string{"x"}}},
+			err: "Invalid job dependency: a depends on unknown job x"},
+		{scenario: "duplicated dependency", jobList: []MigrationJob{{Name: "a"}, {Name: "a"}, {Name: "b", DependsOn: []string{"a"}}},
+			err: "Invalid job dependency: b depends on duplicated job name a"},
+		{scenario: "self dependency", jobList: []MigrationJob{{Name: "a", DependsOn: []string{"a"}}},
+			err: "Invalid job dependency: a depends on itself"},
+		{scenario: "circular dependency", jobList: []MigrationJob{
+			{Name: "a"},
+			{Name: "b", DependsOn: []string{"a", "d"}},
+			{Name: "c", DependsOn: []string{"b"}},
+			{Name: "d", DependsOn: []string{"c"}},
+			{Name: "e", DependsOn: []string{"d"}},
+		}, err: "Circular job dependency: b, c, d, e"},
+	}
+
+	t.Run(">>> validation of jobs dependencies", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			got := ""
+
+			_, err := newJobGraph(test.jobList)
+			if err != nil {
+				got = err.Error()
+			}
+
+			if test.err != got {
+				t.Errorf("fail in newJobGraph(): expected: %s result: %s", test.err, got)
+			}
+		}
+	})
+}
+
+//	Test_JobGraph_Run test cases for running the jobs in dependency order
+func Test_JobGraph_Run(t *testing.T) {
+
+	jobList := []MigrationJob{
+		{Name: "customers"},
+		{Name: "products"},
+		{Name: "orders", DependsOn: []string{"customers", "products"}},
+		{Name: "invoices", DependsOn: []string{"orders"}},
+		{Name: "stock", DependsOn: []string{"products"}},
+		{Name: "audit"},
+	}
+
+	t.Run(">>> validation of dependency order and parallel limit", func(t *testing.T) {
+
+		graph, err := newJobGraph(jobList)
+		if err != nil {
+			t.Errorf("unexpected error in newJobGraph(): %s", err)
+			return
+		}
+
+		var mutex sync.Mutex
+		var running, maxRunning int
+		finished := make(map[string]bool)
+
+		runner := func(ctx context.Context, job *MigrationJob, messages io.Writer) error {
+
+			mutex.Lock()
+			for _, dependency := range job.DependsOn {
+				if !finished[dependency] {
+					t.Errorf("fail in run(): job %s started before %s finished", job.Name, dependency)
+				}
+			}
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+
+			fmt.Fprintf(messages, "job %s\n", job.Name)
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			running--
+			finished[job.Name] = true
+			mutex.Unlock()
+
+			return nil
+		}
+
+		messages := &bytes.Buffer{}
+		graph.run(context.Background(), runner, 2, messages)
+
+		if maxRunning != 2 || len(finished) != len(jobList) {
+			t.Errorf("fail in run(): expected %d jobs, 2 at a time, result: %d jobs, %d at a time", len(jobList), len(finished), maxRunning)
+		}
+		if strings.Count(messages.String(), "job ") != len(jobList) || graph.err() != nil {
+			t.Errorf("fail in run(): unexpected messages: %s", messages.String())
+		}
+	})
+
+	t.Run(">>> validation of dependents of a failed job", func(t *testing.T) {
+
+		graph, err := newJobGraph(jobList)
+		if err != nil {
+			t.Errorf("unexpected error in newJobGraph(): %s", err)
+			return
+		}
+
+		runner := func(ctx context.Context, job *MigrationJob, messages io.Writer) error {
+			if job.Name == "customers" {
+				return errors.New("input file not found")
+			}
+			return nil
+		}
+
+		graph.run(context.Background(), runner, 3, &bytes.Buffer{})
+
+		var got []string
+		for _, node := range graph.nodeList {
+			got = append(got, node.job.Name+"="+job_state_name[node.state])
+		}
+
+		want := "[customers=failed products=finished orders=skipped invoices=skipped stock=finished audit=finished]"
+		if want != fmt.Sprint(got) {
+			t.Errorf("fail in run(): expected: %s result: %v", want, got)
+		}
+
+		wantErr := "fail in job customers: input file not found"
+		if err := graph.err(); err == nil || err.Error() != wantErr {
+			t.Errorf("fail in err(): expected: %s result: %v", wantErr, err)
+		}
+
+		status := &bytes.Buffer{}
+		graph.writeStatus(status)
+
+		if !strings.Contains(status.String(), "invoices   skipped   -         dependency orders skipped") {
+			t.Errorf("fail in writeStatus(): unexpected status table:\n%s", status.String())
+		}
+	})
+
+	t.Run(">>> validation of migration interrupted", func(t *testing.T) {
+
+		graph, err := newJobGraph(jobList)
+		if err != nil {
+			t.Errorf("unexpected error in newJobGraph(): %s", err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		//	the first job is interrupted, and no other job starts
+		started := 0
+		runner := func(ctx context.Context, job *MigrationJob, messages io.Writer) error {
+			started++
+			cancel()
+			return jobInterrupted(ctx)
+		}
+
+		graph.run(ctx, runner, 1, &bytes.Buffer{})
+
+		var got []string
+		for _, node := range graph.nodeList {
+			got = append(got, node.job.Name+"="+job_state_name[node.state])
+		}
+
+		want := "[customers=interrupted products=skipped orders=skipped invoices=skipped stock=skipped audit=skipped]"
+		if want != fmt.Sprint(got) || started != 1 {
+			t.Errorf("fail in run(): expected: %s result: %v (%d jobs started)", want, got, started)
+		}
+
+		wantErr := "fail in job customers: Job interrupted: context canceled"
+		if err := graph.err(); err == nil || err.Error() != wantErr || !errors.Is(err, context.Canceled) {
+			t.Errorf("fail in err(): expected: %s result: %v", wantErr, err)
+		}
+	})
+
+	t.Run(">>> validation of parallel jobs messages", func(t *testing.T) {
+
+		graph, err := newJobGraph([]MigrationJob{{Name: "slow"}, {Name: "fast"}})
+		if err != nil {
+			t.Errorf("unexpected error in newJobGraph(): %s", err)
+			return
+		}
+
+		//	the slow job only finishes after its first message is written
+		proceed := make(chan bool)
+		runner := func(ctx context.Context, job *MigrationJob, messages io.Writer) error {
+			if job.Name == "fast" {
+				fmt.Fprintf(messages, "row 1\nrow 2\n")
+				return nil
+			}
+
+			fmt.Fprintf(messages, "started\n")
+			fmt.Fprintf(messages, "waiting")
+			<-proceed
+			fmt.Fprintf(messages, " done\n")
+
+			return nil
+		}
+
+		messages := &messageChannelWriter{writes: make(chan string, 10)}
+		finished := make(chan bool)
+
+		go func() {
+			graph.run(context.Background(), runner, 2, messages)
+			close(finished)
+		}()
+
+		var got []string
+		for !strings.Contains(strings.Join(got, ""), "[slow] started\n") {
+			select {
+			case message := <-messages.writes:
+				got = append(got, message)
+			case <-time.After(2 * time.Second):
+				t.Errorf("fail in run(): messages not written while the job runs: %v", got)
+				close(proceed)
+				<-finished
+				return
+			}
+		}
+		close(proceed)
+		<-finished
+		close(messages.writes)
+
+		for message := range messages.writes {
+			got = append(got, message)
+		}
+
+		output := strings.Join(got, "")
+		if !strings.Contains(output, "[fast] row 1\n[fast] row 2\n") || !strings.Contains(output, "[slow] waiting done\n") {
+			t.Errorf("fail in run(): unexpected messages: %s", output)
+		}
+	})
+}
+
+//	test writer that sends each write to a channel
+type messageChannelWriter struct {
+	writes chan string
+}
+
+func (w *messageChannelWriter) Write(data []byte) (int, error) {
+
+	w.writes <- string(data)
+
+	return len(data), nil
+}
@@ -0,0 +1,720 @@
+///////////////////////////////////////////////////////////////////////////////
+//	joinStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that joins the job's input with a secondary input
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//	constants for join types
+const (
+	INNER_JOIN = 1
+	LEFT_JOIN  = 2
+	FULL_JOIN  = 3
+)
+
+var (
+	join_type = map[string]uint8{
+		"inner": INNER_JOIN,
+		"left":  LEFT_JOIN,
+		"full":  FULL_JOIN,
+	}
+)
+
+//	constants for join algorithms
+const (
+	HASH_JOIN  = 1
+	MERGE_JOIN = 2
+)
+
+var (
+	join_algorithm = map[string]uint8{
+		"hash":  HASH_JOIN,
+		"merge": MERGE_JOIN,
+	}
+)
+
+//	attributes for a join step configuration
+type JoinConfig struct {
+	JoinType           string        `yaml:"join_type"`
+	Algorithm          string        `yaml:"algorithm"`
+	KeyFields          []string      `yaml:"key_fields"`
+	SecondaryKeyFields []string      `yaml:"secondary_key_fields"`
+	FieldList          []LookupField `yaml:"fields"`
+}
+
+//	attributes for a join step
+type joinStep struct {
+	Config         JoinConfig
+	PrimaryInput   JobInput
+	SecondaryInput JobInput
+
+	NextStep DataPipelineStep
+
+	joinType  uint8
+	algorithm uint8
+	keyTypes  []uint8
+	source    DataInputSource
+	rejecter  RowRejecter
+
+	//	hash join: secondary records indexed by key, in the order they were read
+	index       map[string][]*Row
+	keyOrder    []string
+	matchedKeys map[string]bool
+
+	//	merge join: secondary records read in key order, one group of records with the same key at a time
+	secondary       *rowStream
+	group           []*Row
+	groupKey        []interface{}
+	groupMatched    bool
+	lastPrimaryKey  []interface{}
+	secondaryLoaded bool
+
+	rowsMatched          int64
+	primaryRowsUnmatched int64
+	secondaryUnmatched   int64
+}
+
+//	NewJoinStep create a new joinStep joining the rows from the primary input with the secondary input records
+func NewJoinStep(config JoinConfig, primaryInput JobInput, secondaryInput JobInput) (DataPipelineStep, error) {
+
+	if len(config.JoinType) == 0 {
+		config.JoinType = "inner"
+	}
+	if len(config.Algorithm) == 0 {
+		config.Algorithm = "hash"
+	}
+
+	joinType, found := join_type[config.JoinType]
+	if !found {
+		return nil, errors.New("Invalid join type: " + config.JoinType)
+	}
+
+	algorithm, found := join_algorithm[config.Algorithm]
+	if !found {
+		return nil, errors.New("Invalid join algorithm: " + config.Algorithm)
+	}
+
+	if len(secondaryInput.Type) == 0 {
+		return nil, errors.New("Join step need a job's secondary input")
+	}
+
+	//	validate the key fields
+	if len(config.KeyFields) == 0 {
+		return nil, errors.New("Join step need at least one key field")
+	}
+	if len(config.SecondaryKeyFields) == 0 {
+		config.SecondaryKeyFields = config.KeyFields
+	}
+	if len(config.SecondaryKeyFields) != len(config.KeyFields) {
+		return nil, errors.New("Join key fields don't match secondary key fields")
+	}
+
+	//	if no fields are given, the secondary fields not in the primary input are joined
+	if len(config.FieldList) == 0 {
+		for _, field := range secondaryInput.FieldList {
+			if !hasDataField(primaryInput.FieldList, field.Name) && !isKeyColumn(field.Name, config.SecondaryKeyFields) {
+				config.FieldList = append(config.FieldList, LookupField{Name: field.Name})
+			}
+		}
+	}
+
+	for i, field := range config.FieldList {
+		if len(field.Name) == 0 {
+			return nil, errors.New("Missing join field name")
+		}
+		if len(field.Reference) == 0 {
+			config.FieldList[i].Reference = field.Name
+		}
+	}
+
+	step := &joinStep{
+		Config:         config,
+		PrimaryInput:   primaryInput,
+		SecondaryInput: secondaryInput,
+		joinType:       joinType,
+		algorithm:      algorithm,
+	}
+
+	//	key fields are compared as values of their types in the primary input, or else in the secondary input; the
+	//	fields that aren't in either keep the type of their values
+	for i, keyField := range config.KeyFields {
+		var keyType uint8
+
+		for _, field := range secondaryInput.FieldList {
+			if field.Name == config.SecondaryKeyFields[i] {
+				keyType = data_field_type[field.Type]
+			}
+		}
+		for _, field := range primaryInput.FieldList {
+			if field.Name == keyField {
+				keyType = data_field_type[field.Type]
+			}
+		}
+		step.keyTypes = append(step.keyTypes, keyType)
+	}
+
+	secondary, err := newDataInputSource(secondaryInput)
+	if err != nil {
+		return nil, errors.New("fail loading join secondary input: " + err.Error())
+	}
+
+	err = secondary.ValidateFormat()
+	if err != nil {
+		return nil, errors.New("fail loading join secondary input: " + err.Error())
+	}
+	step.source = secondary
+
+	return step, nil
+}
+
+//	newJoinStepFromConfig create a new joinStep from a job step configuration
+func newJoinStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	var stepConfig JoinConfig
+
+	err := config.Decode(&stepConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJoinStep(stepConfig, job.Job.Input, job.Job.SecondaryInput)
+}
+
+//	hasDataField check if a field is in a field list
+func hasDataField(fieldList []DataField, name string) bool {
+
+	for _, field := range fieldList {
+		if field.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+//	Start load the secondary input for a hash join, or prepare to read it along with the primary rows for a merge join
+func (s *joinStep) Start(job *JobContext) error {
+
+	s.source.SetRowRejecter(failingRejecter{})
+
+	s.rejecter = nil
+	if job != nil {
+		s.rejecter = job.Rejecter
+	}
+
+	if s.algorithm == HASH_JOIN {
+		return s.loadSecondary(contextOf(job), s.source)
+	}
+
+	s.secondary = newRowStream(contextOf(job), s.source)
+
+	return nil
+}
+
+//	loadSecondary read the secondary input into an in-memory index for a hash join
+func (s *joinStep) loadSecondary(ctx context.Context, secondary DataInputSource) error {
+
+	s.index = make(map[string][]*Row)
+	s.matchedKeys = make(map[string]bool)
+
+	collector := &rowCopyStep{
+		process: func(record *Row) error {
+			//	records with null keys don't match any row, but are still sent by full joins
+			key, matchable := hashKey(s.joinKey(record, s.Config.SecondaryKeyFields))
+			if !matchable {
+				key = nullHashKey + strconv.Itoa(len(s.keyOrder))
+			}
+
+			_, found := s.index[key]
+			if !found {
+				s.keyOrder = append(s.keyOrder, key)
+			}
+			s.index[key] = append(s.index[key], record)
+
+			return nil
+		},
+	}
+
+	_, err := secondary.ImportData(ctx, collector)
+	if err != nil {
+		return errors.New("fail loading join secondary input: " + err.Error())
+	}
+
+	return nil
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *joinStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *joinStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow join the row with the matching secondary records
+func (s *joinStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	var matches []*Row
+
+	if s.algorithm == HASH_JOIN {
+		key, matchable := hashKey(s.joinKey(row, s.Config.KeyFields))
+
+		if matchable {
+			matches = s.index[key]
+		}
+		if len(matches) > 0 {
+			s.matchedKeys[key] = true
+		}
+	} else {
+		matches, err = s.mergeMatches(row)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(matches) == 0 {
+		s.primaryRowsUnmatched++
+
+		if s.joinType == INNER_JOIN {
+			return false, nil
+		}
+
+		for _, field := range s.Config.FieldList {
+			row.Set(field.Name, field.missValue())
+		}
+
+		return s.nextStep(row)
+	}
+
+	//	one row is sent to the next step for every matching secondary record
+	for _, record := range matches {
+		s.rowsMatched++
+
+		for _, field := range s.Config.FieldList {
+			row.Set(field.Name, record.Get(field.Reference))
+		}
+
+		rowProcessed, err = s.nextStep(row)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return rowProcessed, nil
+}
+
+//	Finish send the unmatched secondary records to the next step for full outer joins, and stop reading the secondary input
+func (s *joinStep) Finish(jobErr error) error {
+
+	//	when the job failed, the secondary input is just released
+	if jobErr != nil {
+		s.index = nil
+		if s.secondary != nil {
+			s.secondary.stop()
+		}
+
+		return nil
+	}
+
+	if s.algorithm == HASH_JOIN {
+		if s.joinType != FULL_JOIN {
+			return nil
+		}
+
+		for _, key := range s.keyOrder {
+			if s.matchedKeys[key] {
+				continue
+			}
+
+			err := s.unmatchedSecondary(s.index[key])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	//	for merge joins, read what is left of the secondary input
+	if s.joinType != FULL_JOIN {
+		return s.secondary.stop()
+	}
+
+	_, err := s.mergeMatches(nil)
+	if err != nil {
+		return err
+	}
+
+	return s.secondary.stop()
+}
+
+//	Summary report the number of rows matched and unmatched
+func (s *joinStep) Summary() string {
+
+	return fmt.Sprintf("Join %s: %d rows matched, %d primary rows unmatched, %d secondary rows unmatched",
+		strings.Join(s.Config.KeyFields, ", "), s.rowsMatched, s.primaryRowsUnmatched, s.secondaryUnmatched)
+}
+
+//	nextStep if available, invoke the next step in the pipeline
+func (s *joinStep) nextStep(row *Row) (rowProcessed bool, err error) {
+
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	unmatchedSecondary send secondary records without a primary row to the next step, with null primary fields;
+//	the rows the next step fails to process are rejected as secondary records
+func (s *joinStep) unmatchedSecondary(records []*Row) error {
+
+	for _, record := range records {
+		s.secondaryUnmatched++
+
+		row := NewRow()
+		row.Source = record.Source
+		row.LineNumber = record.LineNumber
+		row.Record = record.Record
+
+		for _, field := range s.PrimaryInput.FieldList {
+			row.Set(field.Name, nil)
+		}
+		for i, keyField := range s.Config.KeyFields {
+			row.Set(keyField, record.Get(s.Config.SecondaryKeyFields[i]))
+		}
+		for _, field := range s.Config.FieldList {
+			row.Set(field.Name, record.Get(field.Reference))
+		}
+
+		err := forwardRow(s.NextStep, s.rejecter, row)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//	mergeMatches advance the sorted secondary input up to the row's key, and get the records with the same key;
+//	a nil row reads the secondary input to the end
+func (s *joinStep) mergeMatches(row *Row) ([]*Row, error) {
+
+	var key []interface{}
+
+	if row != nil {
+		key = s.joinKey(row, s.Config.KeyFields)
+
+		if s.lastPrimaryKey != nil && s.compareKeys(key, s.lastPrimaryKey) < 0 {
+			return nil, NewFatalError(errors.New("Join input not sorted by key: " + strings.Join(joinKeyValues(row, s.Config.KeyFields), ", ")))
+		}
+		s.lastPrimaryKey = key
+	}
+
+	if !s.secondaryLoaded {
+		err := s.nextGroup()
+		if err != nil {
+			return nil, err
+		}
+		s.secondaryLoaded = true
+	}
+
+	for s.group != nil && (row == nil || s.compareKeys(s.groupKey, key) < 0) {
+		if !s.groupMatched && s.joinType == FULL_JOIN {
+			err := s.unmatchedSecondary(s.group)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err := s.nextGroup()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//	null keys don't match any record
+	if s.group == nil || row == nil || hasNullKey(key) || s.compareKeys(s.groupKey, key) != 0 {
+		return nil, nil
+	}
+	s.groupMatched = true
+
+	return s.group, nil
+}
+
+//	nextGroup read the next group of secondary records with the same key
+func (s *joinStep) nextGroup() error {
+
+	var group []*Row
+	var groupKey []interface{}
+
+	for {
+		record, err := s.secondary.next()
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			break
+		}
+
+		key := s.joinKey(record, s.Config.SecondaryKeyFields)
+
+		if s.groupKey != nil && s.compareKeys(key, s.groupKey) < 0 {
+			return NewFatalError(errors.New("Join secondary input not sorted by key: " + strings.Join(joinKeyValues(record, s.Config.SecondaryKeyFields), ", ")))
+		}
+
+		if groupKey != nil && s.compareKeys(key, groupKey) != 0 {
+			s.secondary.pending = record
+			break
+		}
+
+		groupKey = key
+		group = append(group, record)
+	}
+
+	s.group = group
+	s.groupKey = groupKey
+	s.groupMatched = false
+
+	return nil
+}
+
+//	joinKeyValues get the key field values, ignoring padding spaces
+func joinKeyValues(row *Row, keyFields []string) []string {
+
+	var key []string
+
+	for _, field := range keyFields {
+		key = append(key, strings.TrimSpace(row.GetString(field)))
+	}
+
+	return key
+}
+
+//	keyFieldValues get the typed key field values
+func keyFieldValues(row *Row, keyFields []string) []interface{} {
+
+	var key []interface{}
+
+	for _, field := range keyFields {
+		key = append(key, row.Get(field))
+	}
+
+	return key
+}
+
+//	joinKey get the key field values converted to the key types, so both join algorithms compare the same values:
+//	texts are read as values of the key type, and the values of string keys are compared by their text, all
+//	ignoring padding spaces
+func (s *joinStep) joinKey(row *Row, keyFields []string) []interface{} {
+
+	var key []interface{}
+
+	for i, field := range keyFields {
+		value := row.Get(field)
+		keyType := s.keyTypes[i]
+
+		if value != nil && keyType == STRING {
+			value = exprString(value)
+		}
+
+		if text, isString := value.(string); isString {
+			value = strings.TrimSpace(text)
+
+			if keyType != STRING && keyType != 0 {
+				typedValue, err := parseFieldValue(keyType, "", text)
+				if err == nil {
+					value = typedValue
+				}
+			}
+		}
+
+		key = append(key, value)
+	}
+
+	return key
+}
+
+//	prefix of the hash join index keys of the secondary records with null keys, which no key encodes to
+const nullHashKey = "\x00null:"
+
+//	hashKey encode a key for the hash join index, with the same encoding for the values compareRowValues finds
+//	equal; keys with nulls can't be matched
+func hashKey(key []interface{}) (string, bool) {
+
+	var hash strings.Builder
+
+	for _, value := range key {
+		switch typedValue := value.(type) {
+		case nil:
+			return "", false
+
+		case int64:
+			hash.WriteString("n" + strconv.FormatInt(typedValue, 10))
+
+		case Decimal:
+			hash.WriteString("n" + typedValue.trim(0).String())
+
+		case time.Time:
+			hash.WriteString("t" + typedValue.UTC().Format(time.RFC3339Nano))
+
+		case bool:
+			hash.WriteString("b" + strconv.FormatBool(typedValue))
+
+		default:
+			hash.WriteString("s" + exprString(value))
+		}
+		hash.WriteString(lookupKeySeparator)
+	}
+
+	return hash.String(), true
+}
+
+//	hasNullKey check if any of the key values is null
+func hasNullKey(key []interface{}) bool {
+
+	for _, value := range key {
+		if value == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+//	compareKeys compare two keys field by field, by their typed values
+func (s *joinStep) compareKeys(key1 []interface{}, key2 []interface{}) int {
+
+	for i := range key1 {
+		result := compareRowValues(key1[i], key2[i], s.keyTypes[i])
+		if result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+//	pipeline step that hands a copy of each row to a function
+type rowCopyStep struct {
+	process func(row *Row) error
+}
+
+func (s *rowCopyStep) SetNextStep(nextStep DataPipelineStep) {
+}
+
+func (s *rowCopyStep) GetNextStep() DataPipelineStep {
+	return nil
+}
+
+func (s *rowCopyStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	err = s.process(row.Copy())
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+//	errRowStreamStopped stops an input source feeding a row stream that's no longer read
+var errRowStreamStopped = NewFatalError(errors.New("Row stream stopped"))
+
+//	attributes for a row stream: the rows of an input source, read one at a time
+type rowStream struct {
+	ctx     context.Context
+	source  DataInputSource
+	rows    chan *Row
+	done    chan struct{}
+	err     error
+	started bool
+	pending *Row
+}
+
+//	newRowStream create a new rowStream for an input source
+func newRowStream(ctx context.Context, source DataInputSource) *rowStream {
+
+	return &rowStream{
+		ctx:    ctx,
+		source: source,
+		rows:   make(chan *Row, 100),
+		done:   make(chan struct{}),
+	}
+}
+
+//	start import the input source rows in a goroutine
+func (r *rowStream) start() {
+
+	r.started = true
+
+	go func() {
+		defer close(r.rows)
+
+		_, r.err = r.source.ImportData(r.ctx, &rowCopyStep{
+			process: func(row *Row) error {
+				select {
+				case r.rows <- row:
+					return nil
+
+				case <-r.done:
+					return errRowStreamStopped
+				}
+			},
+		})
+	}()
+}
+
+//	next get the next row from the input source, or nil at the end of the input
+func (r *rowStream) next() (*Row, error) {
+
+	if r.pending != nil {
+		row := r.pending
+		r.pending = nil
+
+		return row, nil
+	}
+
+	if !r.started {
+		r.start()
+	}
+
+	row, ok := <-r.rows
+	if !ok {
+		if r.err != nil {
+			return nil, NewFatalError(errors.New("fail reading join secondary input: " + r.err.Error()))
+		}
+		return nil, nil
+	}
+
+	return row, nil
+}
+
+//	stop stop reading the input source and wait for its goroutine to finish
+func (r *rowStream) stop() error {
+
+	if !r.started {
+		return nil
+	}
+
+	close(r.done)
+	for range r.rows {
+	}
+
+	if r.err != nil && !errors.Is(r.err, errRowStreamStopped) {
+		return errors.New("fail reading join secondary input: " + r.err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,295 @@
+///////////////////////////////////////////////////////////////////////////////
+//	joinStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the join pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+//	Test_JoinStep_ProcessRow test cases for the join step
+func Test_JoinStep_ProcessRow(t *testing.T) {
+
+	testFiles := map[string]string{
+		"testCustomer.txt": "1,Ann\n2,Bob\n10,Dan\n",
+		"testAccount.txt":  "1,A1\n1,A2\n3,C1\n10,D1\n",
+		"testUnsorted.txt": "10,D1\n1,A1\n",
+		"testInvalid.txt":  "1,A1\nX,B1\n",
+		"testPadded.txt":   "001,A1\n010,D1\n",
+		"testNames.txt":    ",E1\nAnn,A1\n",
+	}
+
+	for fileName, content := range testFiles {
+		err := os.WriteFile(fileName, []byte(content), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(fileName)
+	}
+
+	customer := JobInput{
+		Type:           "CSVFile",
+		FileName:       "testCustomer.txt",
+		FieldSeparator: ",",
+		FieldList:      []DataField{{Name: "id", Type: "integer"}, {Name: "name", Type: "string"}},
+	}
+
+	account := JobInput{
+		Type:           "CSVFile",
+		FileName:       "testAccount.txt",
+		FieldSeparator: ",",
+		FieldList:      []DataField{{Name: "customer_id", Type: "integer"}, {Name: "account", Type: "string"}},
+	}
+
+	noAccount := "-"
+
+	unsorted := account
+	unsorted.FileName = "testUnsorted.txt"
+
+	invalid := account
+	invalid.FileName = "testInvalid.txt"
+
+	//	text keys are compared as integers, the type of the primary key field
+	padded := account
+	padded.FileName = "testPadded.txt"
+	padded.FieldList = []DataField{{Name: "customer_id", Type: "string"}, {Name: "account", Type: "string"}}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario  string
+		joinType  string
+		algorithm string
+		secondary JobInput
+		output    string
+	}{
+		{scenario: "hash inner join", joinType: "inner", algorithm: "hash", secondary: account,
+			output: "1:Ann:A1 1:Ann:A2 10:Dan:D1"},
+		{scenario: "hash left join", joinType: "left", algorithm: "hash", secondary: account,
+			output: "1:Ann:A1 1:Ann:A2 2:Bob:- 10:Dan:D1"},
+		{scenario: "hash full join", joinType: "full", algorithm: "hash", secondary: account,
+			output: "1:Ann:A1 1:Ann:A2 2:Bob:- 10:Dan:D1 3::C1"},
+		{scenario: "merge inner join", joinType: "inner", algorithm: "merge", secondary: account,
+			output: "1:Ann:A1 1:Ann:A2 10:Dan:D1"},
+		{scenario: "merge left join", joinType: "left", algorithm: "merge", secondary: account,
+			output: "1:Ann:A1 1:Ann:A2 2:Bob:- 10:Dan:D1"},
+		{scenario: "merge full join", joinType: "full", algorithm: "merge", secondary: account,
+			output: "1:Ann:A1 1:Ann:A2 2:Bob:- 3::C1 10:Dan:D1"},
+		{scenario: "hash join on text keys", joinType: "inner", algorithm: "hash", secondary: padded,
+			output: "1:Ann:A1 10:Dan:D1"},
+		{scenario: "merge join on text keys", joinType: "inner", algorithm: "merge", secondary: padded,
+			output: "1:Ann:A1 10:Dan:D1"},
+		{scenario: "merge join with unsorted input", joinType: "full", algorithm: "merge", secondary: unsorted,
+			output: "Join secondary input not sorted by key: 1"},
+		{scenario: "hash join with invalid secondary record", joinType: "inner", algorithm: "hash", secondary: invalid,
+			output: "fail loading join secondary input: testInvalid.txt, line 2: Invalid value for field customer_id: not an integer: X"},
+		{scenario: "merge join with invalid secondary record", joinType: "inner", algorithm: "merge", secondary: invalid,
+			output: "fail reading join secondary input: testInvalid.txt, line 2: Invalid value for field customer_id: not an integer: X"},
+	}
+
+	t.Run(">>> validation of join step", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			collector := &rowCollector{}
+
+			step, err := NewJoinStep(JoinConfig{
+				JoinType:           test.joinType,
+				Algorithm:          test.algorithm,
+				KeyFields:          []string{"id"},
+				SecondaryKeyFields: []string{"customer_id"},
+				FieldList:          []LookupField{{Name: "account", Default: &noAccount}},
+			}, customer, test.secondary)
+			if err != nil {
+				t.Errorf("unexpected error in NewJoinStep(): %s", err)
+				continue
+			}
+			step.SetNextStep(collector)
+
+			//	the merge join stops at the first row out of order
+			rowErr := startPipeline(step, nil)
+
+			for _, row := range []map[string]string{{"id": "1", "name": "Ann"}, {"id": "2", "name": "Bob"}, {"id": "10", "name": "Dan"}} {
+				if rowErr != nil {
+					break
+				}
+				_, rowErr = step.ProcessRow(newTestRow(row))
+			}
+
+			finishErr := finishPipeline(step, rowErr)
+			if rowErr == nil {
+				rowErr = finishErr
+			}
+
+			got := ""
+			want := test.output
+
+			if rowErr != nil {
+				got = rowErr.Error()
+			} else {
+				var rowList []string
+
+				for _, row := range collector.rows {
+					rowList = append(rowList, row.GetString("id")+":"+row.GetString("name")+":"+row.GetString("account"))
+				}
+				got = strings.Join(rowList, " ")
+			}
+
+			if want != got {
+				t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+
+	t.Run(">>> validation of unmatched rows without default", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		step, err := NewJoinStep(JoinConfig{
+			JoinType:           "left",
+			KeyFields:          []string{"id"},
+			SecondaryKeyFields: []string{"customer_id"},
+			FieldList:          []LookupField{{Name: "account"}},
+		}, customer, account)
+		if err != nil {
+			t.Errorf("unexpected error in NewJoinStep(): %s", err)
+			return
+		}
+		step.SetNextStep(collector)
+
+		err = startPipeline(step, nil)
+		if err == nil {
+			_, err = step.ProcessRow(newTestRow(map[string]string{"id": "2", "name": "Bob"}))
+		}
+		finishPipeline(step, err)
+
+		if err != nil || len(collector.rows) != 1 || collector.rows[0].Get("account") != nil {
+			t.Errorf("fail in ProcessRow(): expected null account, result: %v %v", collector.rows, err)
+		}
+	})
+
+	t.Run(">>> validation of merge join on derived typed keys", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		//	the code field isn't a primary input field, so its keys are compared by their type
+		step, err := NewJoinStep(JoinConfig{
+			JoinType:           "inner",
+			Algorithm:          "merge",
+			KeyFields:          []string{"code"},
+			SecondaryKeyFields: []string{"customer_id"},
+			FieldList:          []LookupField{{Name: "account"}},
+		}, customer, account)
+		if err != nil {
+			t.Errorf("unexpected error in NewJoinStep(): %s", err)
+			return
+		}
+		step.SetNextStep(collector)
+
+		err = startPipeline(step, nil)
+		for _, code := range []int64{1, 2, 10} {
+			if err != nil {
+				break
+			}
+
+			row := NewRow()
+			row.Set("code", code)
+			_, err = step.ProcessRow(row)
+		}
+		finishErr := finishPipeline(step, err)
+		if err == nil {
+			err = finishErr
+		}
+
+		var rowList []string
+		for _, row := range collector.rows {
+			rowList = append(rowList, row.GetString("code")+":"+row.GetString("account"))
+		}
+
+		want := "1:A1 1:A2 10:D1"
+		got := strings.Join(rowList, " ")
+
+		if err != nil || want != got {
+			t.Errorf("fail in ProcessRow(): expected: %s result: %s %v", want, got, err)
+		}
+	})
+
+	t.Run(">>> validation of null keys", func(t *testing.T) {
+
+		names := JobInput{
+			Type:           "CSVFile",
+			FileName:       "testNames.txt",
+			FieldSeparator: ",",
+			FieldList:      []DataField{{Name: "name", Type: "string"}, {Name: "account", Type: "string"}},
+		}
+
+		for _, algorithm := range []string{"hash", "merge"} {
+
+			fmt.Printf("scenario: %s join with null keys\n", algorithm)
+
+			collector := &rowCollector{}
+
+			//	a null key doesn't match the empty name
+			step, err := NewJoinStep(JoinConfig{
+				JoinType:  "inner",
+				Algorithm: algorithm,
+				KeyFields: []string{"name"},
+				FieldList: []LookupField{{Name: "account"}},
+			}, customer, names)
+			if err != nil {
+				t.Errorf("unexpected error in NewJoinStep(): %s", err)
+				return
+			}
+			step.SetNextStep(collector)
+
+			err = startPipeline(step, nil)
+			for _, name := range []interface{}{nil, "", "Ann"} {
+				if err != nil {
+					break
+				}
+
+				row := NewRow()
+				row.Set("name", name)
+				_, err = step.ProcessRow(row)
+			}
+			finishErr := finishPipeline(step, err)
+			if err == nil {
+				err = finishErr
+			}
+
+			var rowList []string
+			for _, row := range collector.rows {
+				rowList = append(rowList, row.GetString("name")+":"+row.GetString("account"))
+			}
+
+			want := ":E1 Ann:A1"
+			got := strings.Join(rowList, " ")
+
+			if err != nil || want != got {
+				t.Errorf("fail in ProcessRow(): expected: %s result: %s %v", want, got, err)
+			}
+		}
+	})
+
+	t.Run(">>> validation of join without secondary input", func(t *testing.T) {
+
+		got := ""
+		want := "Join step need a job's secondary input"
+
+		_, err := NewJoinStep(JoinConfig{KeyFields: []string{"id"}}, customer, JobInput{})
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in NewJoinStep(): expected: %s result: %s", want, got)
+		}
+	})
+}
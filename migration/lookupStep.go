@@ -0,0 +1,271 @@
+///////////////////////////////////////////////////////////////////////////////
+//	lookupStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that enriches rows from a reference data set
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+//	constants for lookup miss actions
+const (
+	LOOKUP_DEFAULT = 1
+	LOOKUP_REJECT  = 2
+	LOOKUP_FAIL    = 3
+)
+
+var (
+	lookup_miss_action = map[string]uint8{
+		"default": LOOKUP_DEFAULT,
+		"reject":  LOOKUP_REJECT,
+		"fail":    LOOKUP_FAIL,
+	}
+)
+
+//	attributes for a field copied from the reference record: without a default, missed fields are null
+type LookupField struct {
+	Name      string  `yaml:"name"`
+	Reference string  `yaml:"reference"`
+	Default   *string `yaml:"default"`
+}
+
+//	missValue get the value of the field when there's no matching record
+func (f LookupField) missValue() interface{} {
+
+	if f.Default == nil {
+		return nil
+	}
+
+	return *f.Default
+}
+
+//	attributes for a lookup step configuration
+type LookupConfig struct {
+	Reference          JobInput      `yaml:"reference"`
+	KeyFields          []string      `yaml:"key_fields"`
+	ReferenceKeyFields []string      `yaml:"reference_key_fields"`
+	FieldList          []LookupField `yaml:"fields"`
+	OnMiss             string        `yaml:"on_miss"`
+}
+
+//	attributes for a lookup step
+type lookupStep struct {
+	Config LookupConfig
+
+	NextStep DataPipelineStep
+
+	missAction   uint8
+	reference    DataInputSource
+	index        map[string]*Row
+	rowsMatched  int64
+	rowsMissed   int64
+	rowsRejected int64
+}
+
+//	NewLookupStep create a new lookupStep; the reference data set is loaded into an in-memory index when the step starts
+func NewLookupStep(config LookupConfig) (DataPipelineStep, error) {
+
+	if len(config.OnMiss) == 0 {
+		config.OnMiss = "default"
+	}
+
+	missAction, found := lookup_miss_action[config.OnMiss]
+	if !found {
+		return nil, errors.New("Invalid lookup miss action: " + config.OnMiss)
+	}
+
+	//	validate the key fields
+	if len(config.KeyFields) == 0 {
+		return nil, errors.New("Lookup step need at least one key field")
+	}
+	if len(config.ReferenceKeyFields) == 0 {
+		config.ReferenceKeyFields = config.KeyFields
+	}
+	if len(config.ReferenceKeyFields) != len(config.KeyFields) {
+		return nil, errors.New("Lookup key fields don't match reference key fields")
+	}
+
+	//	validate the fields copied from the reference
+	if len(config.FieldList) == 0 {
+		return nil, errors.New("Lookup step need at least one field")
+	}
+
+	for i, field := range config.FieldList {
+		if len(field.Name) == 0 {
+			return nil, errors.New("Missing lookup field name")
+		}
+		if len(field.Reference) == 0 {
+			config.FieldList[i].Reference = field.Name
+		}
+	}
+
+	reference, err := newDataInputSource(config.Reference)
+	if err != nil {
+		return nil, errors.New("fail loading lookup reference: " + err.Error())
+	}
+
+	err = reference.ValidateFormat()
+	if err != nil {
+		return nil, errors.New("fail loading lookup reference: " + err.Error())
+	}
+
+	return &lookupStep{
+		Config:     config,
+		missAction: missAction,
+		reference:  reference,
+	}, nil
+}
+
+//	newLookupStepFromConfig create a new lookupStep from a job step configuration
+func newLookupStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	var stepConfig LookupConfig
+
+	err := config.Decode(&stepConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLookupStep(stepConfig)
+}
+
+//	Start read the reference data set and index its records by the reference key fields
+func (s *lookupStep) Start(job *JobContext) error {
+
+	index := &lookupIndex{
+		keyFields: s.Config.ReferenceKeyFields,
+		records:   make(map[string]*Row),
+	}
+
+	s.reference.SetRowRejecter(failingRejecter{})
+
+	_, err := s.reference.ImportData(contextOf(job), index)
+	if err != nil {
+		return errors.New("fail loading lookup reference: " + err.Error())
+	}
+
+	s.index = index.records
+
+	return nil
+}
+
+//	Finish release the reference index
+func (s *lookupStep) Finish(jobErr error) error {
+
+	s.index = nil
+
+	return nil
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *lookupStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *lookupStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow find the reference record matching the row's key and copy its fields to the row
+func (s *lookupStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	key := lookupKey(row, s.Config.KeyFields)
+
+	record, found := s.index[key]
+	if found {
+		atomic.AddInt64(&s.rowsMatched, 1)
+
+		for _, field := range s.Config.FieldList {
+			row.Set(field.Name, record.Get(field.Reference))
+		}
+	} else {
+		atomic.AddInt64(&s.rowsMissed, 1)
+
+		missErr := errors.New("Lookup key not found in reference: " + strings.ReplaceAll(key, lookupKeySeparator, ", "))
+
+		switch s.missAction {
+		case LOOKUP_REJECT:
+			atomic.AddInt64(&s.rowsRejected, 1)
+			return false, missErr
+
+		case LOOKUP_FAIL:
+			return false, NewFatalError(missErr)
+		}
+
+		for _, field := range s.Config.FieldList {
+			row.Set(field.Name, field.missValue())
+		}
+	}
+
+	//	if available, invoke the next step in the pipeline
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	ConcurrentSafe the reference index is only read once the step starts, so rows can be looked up concurrently
+func (s *lookupStep) ConcurrentSafe() bool {
+	return true
+}
+
+//	Summary report the number of rows matched and missed
+func (s *lookupStep) Summary() string {
+
+	return fmt.Sprintf("Lookup %s: %d rows matched, %d rows missed, %d rows rejected",
+		strings.Join(s.Config.KeyFields, ", "), s.rowsMatched, s.rowsMissed, s.rowsRejected)
+}
+
+//	separator for the values of a compound lookup key
+const lookupKeySeparator = "\x00"
+
+//	lookupKey build the index key from the key fields values, ignoring padding spaces
+func lookupKey(row *Row, keyFields []string) string {
+
+	var key strings.Builder
+
+	for i, field := range keyFields {
+		if i > 0 {
+			key.WriteString(lookupKeySeparator)
+		}
+		key.WriteString(strings.TrimSpace(row.GetString(field)))
+	}
+
+	return key.String()
+}
+
+//	pipeline step used to index the reference records while they're imported
+type lookupIndex struct {
+	keyFields []string
+	records   map[string]*Row
+}
+
+func (s *lookupIndex) SetNextStep(nextStep DataPipelineStep) {
+}
+
+func (s *lookupIndex) GetNextStep() DataPipelineStep {
+	return nil
+}
+
+//	ProcessRow copy the reference record into the index
+func (s *lookupIndex) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	key := lookupKey(row, s.keyFields)
+
+	_, found := s.records[key]
+	if found {
+		return false, NewFatalError(errors.New("Duplicated key in lookup reference: " + strings.ReplaceAll(key, lookupKeySeparator, ", ")))
+	}
+
+	s.records[key] = row.Copy()
+
+	return true, nil
+}
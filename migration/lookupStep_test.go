@@ -0,0 +1,164 @@
+///////////////////////////////////////////////////////////////////////////////
+//	lookupStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the lookup pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+//	Test_LookupStep_ProcessRow test cases for the lookup step
+func Test_LookupStep_ProcessRow(t *testing.T) {
+
+	const referenceFileName = "testReference.txt"
+
+	err := os.WriteFile(referenceFileName, []byte("001;SP;1001\n002;SP;1002\n001;RJ;2001\n"), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating reference file: %s", err)
+	}
+	defer os.Remove(referenceFileName)
+
+	reference := JobInput{
+		Type:           "CSVFile",
+		FileName:       referenceFileName,
+		FieldSeparator: ";",
+		FieldList: []DataField{
+			{Name: "code", Type: "string"},
+			{Name: "state", Type: "string"},
+			{Name: "branch_id", Type: "integer"},
+		},
+	}
+
+	defaultBranch := "0"
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario  string
+		onMiss    string
+		noDefault bool
+		input     map[string]string
+		output    string
+	}{
+		{scenario: "compound key match", input: map[string]string{"branch": " 002", "state": "SP"}, output: "true 1002"},
+		{scenario: "miss with default", input: map[string]string{"branch": "003", "state": "SP"}, output: "true 0"},
+		{scenario: "miss without default", noDefault: true, input: map[string]string{"branch": "003", "state": "SP"}, output: "true <nil>"},
+		{scenario: "miss with reject", onMiss: "reject", input: map[string]string{"branch": "002", "state": "RJ"},
+			output: "Lookup key not found in reference: 002, RJ"},
+		{scenario: "miss with fail", onMiss: "fail", input: map[string]string{"branch": "002", "state": "RJ"},
+			output: "fatal: Lookup key not found in reference: 002, RJ"},
+	}
+
+	t.Run(">>> validation of lookup step", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			field := LookupField{Name: "new_branch", Reference: "branch_id", Default: &defaultBranch}
+			if test.noDefault {
+				field.Default = nil
+			}
+
+			step, err := NewLookupStep(LookupConfig{
+				Reference:          reference,
+				KeyFields:          []string{"branch", "state"},
+				ReferenceKeyFields: []string{"code", "state"},
+				FieldList:          []LookupField{field},
+				OnMiss:             test.onMiss,
+			})
+			if err != nil {
+				t.Errorf("unexpected error in NewLookupStep(): %s", err)
+				continue
+			}
+
+			err = startPipeline(step, nil)
+			if err != nil {
+				t.Errorf("unexpected error in Start(): %s", err)
+				continue
+			}
+
+			got := ""
+			want := test.output
+
+			row := newTestRow(test.input)
+
+			rowProcessed, err := step.ProcessRow(row)
+			if err != nil {
+				got = err.Error()
+				if isFatalError(err) {
+					got = "fatal: " + got
+				}
+			} else {
+				got = fmt.Sprintf("%v %v", rowProcessed, row.Get("new_branch"))
+			}
+
+			if want != got {
+				t.Errorf("fail in ProcessRow(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+
+	t.Run(">>> validation of invalid reference record", func(t *testing.T) {
+
+		err := os.WriteFile("testInvalid.txt", []byte("001;SP;1001\n002;SP;X\n"), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating reference file: %s", err)
+		}
+		defer os.Remove("testInvalid.txt")
+
+		invalid := reference
+		invalid.FileName = "testInvalid.txt"
+
+		got := ""
+		want := "fail loading lookup reference: testInvalid.txt, line 2: Invalid value for field branch_id: not an integer: X"
+
+		step, err := NewLookupStep(LookupConfig{
+			Reference: invalid,
+			KeyFields: []string{"code", "state"},
+			FieldList: []LookupField{{Name: "branch_id"}},
+		})
+		if err != nil {
+			t.Errorf("unexpected error in NewLookupStep(): %s", err)
+			return
+		}
+
+		err = startPipeline(step, nil)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in Start(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of duplicated reference key", func(t *testing.T) {
+
+		got := ""
+		want := "fail loading lookup reference: testReference.txt, line 3: Duplicated key in lookup reference: 001"
+
+		step, err := NewLookupStep(LookupConfig{
+			Reference: reference,
+			KeyFields: []string{"code"},
+			FieldList: []LookupField{{Name: "branch_id"}},
+		})
+		if err != nil {
+			t.Errorf("unexpected error in NewLookupStep(): %s", err)
+			return
+		}
+
+		err = startPipeline(step, nil)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in Start(): expected: %s result: %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,301 @@
+///////////////////////////////////////////////////////////////////////////////
+//	parallelStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that runs a sequence of steps in a pool of workers
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//	number of rows queued for each worker before the input has to wait
+const parallelRowsPerWorker = 100
+
+//	attributes of a row sent to the workers
+type parallelRow struct {
+	sequence  int64
+	row       *Row
+	processed bool
+	err       error
+}
+
+//	attributes for a parallel step
+type parallelStep struct {
+	Workers int
+
+	NextStep DataPipelineStep
+
+	segment   DataPipelineStep
+	rejecter  RowRejecter
+	sequence  int64
+	rowsQueue chan *parallelRow
+	results   chan *parallelRow
+	workers   sync.WaitGroup
+	done      chan bool
+	mutex     sync.Mutex
+	emitted   int64
+	drained   *sync.Cond
+	err       error
+	started   bool
+}
+
+//	NewParallelStep create a new parallelStep to run the steps starting at segment in a pool of workers; the rows
+//	leave the pool in the same order they came in
+func NewParallelStep(workers int, segment DataPipelineStep) (DataPipelineStep, error) {
+
+	if workers < 1 {
+		return nil, errors.New("Invalid number of workers: " + strconv.Itoa(workers))
+	}
+
+	if segment == nil {
+		return nil, errors.New("Missing parallel pipeline steps")
+	}
+
+	for step := segment; step != nil; step = step.GetNextStep() {
+		if !isConcurrentSafe(step) {
+			return nil, errors.New("Pipeline step can't run in parallel: " + fmt.Sprintf("%T", step))
+		}
+	}
+
+	return &parallelStep{
+		Workers: workers,
+		segment: segment,
+	}, nil
+}
+
+//	isConcurrentSafe check if a step can process rows from several goroutines at the same time
+func isConcurrentSafe(step DataPipelineStep) bool {
+
+	concurrentStep, ok := step.(DataPipelineStepConcurrent)
+
+	return ok && concurrentStep.ConcurrentSafe()
+}
+
+//	parallelizeSteps replace the first sequence of steps that can run concurrently by a parallelStep
+func parallelizeSteps(stepList []DataPipelineStep, workers int) ([]DataPipelineStep, error) {
+
+	first := 0
+	for first < len(stepList) && !isConcurrentSafe(stepList[first]) {
+		first++
+	}
+	if first == len(stepList) {
+		return stepList, nil
+	}
+
+	last := first
+	for last < len(stepList) && isConcurrentSafe(stepList[last]) {
+		last++
+	}
+
+	//	the steps in the pool are chained among themselves only
+	for i := first; i < last-1; i++ {
+		stepList[i].SetNextStep(stepList[i+1])
+	}
+	stepList[last-1].SetNextStep(nil)
+
+	step, err := NewParallelStep(workers, stepList[first])
+	if err != nil {
+		return nil, err
+	}
+
+	var parallelList []DataPipelineStep
+
+	parallelList = append(parallelList, stepList[:first]...)
+	parallelList = append(parallelList, step)
+	parallelList = append(parallelList, stepList[last:]...)
+
+	return parallelList, nil
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *parallelStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *parallelStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	Start start the steps run by the workers, the workers and the goroutine that puts the rows back in order
+func (s *parallelStep) Start(job *JobContext) error {
+
+	err := startPipeline(s.segment, job)
+	if err != nil {
+		return err
+	}
+
+	s.rejecter = nil
+	if job != nil {
+		s.rejecter = job.Rejecter
+	}
+
+	s.sequence = 0
+	s.emitted = 0
+	s.drained = sync.NewCond(&s.mutex)
+	s.err = nil
+	s.rowsQueue = make(chan *parallelRow, s.Workers*parallelRowsPerWorker)
+	s.results = make(chan *parallelRow, s.Workers*parallelRowsPerWorker)
+	s.done = make(chan bool)
+
+	for i := 0; i < s.Workers; i++ {
+		s.workers.Add(1)
+		go s.processRows()
+	}
+	go s.sequenceRows()
+
+	s.started = true
+
+	return nil
+}
+
+//	ProcessRow queue a copy of the row to the workers; a fatal error in a row already queued stops the import
+func (s *parallelStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	err = s.getError()
+	if err != nil {
+		return false, err
+	}
+
+	s.rowsQueue <- &parallelRow{
+		sequence: s.sequence,
+		row:      row.Copy(),
+	}
+	s.sequence++
+
+	return true, nil
+}
+
+//	Finish wait for the rows queued to be processed and finish the steps run by the workers
+func (s *parallelStep) Finish(jobErr error) error {
+
+	if s.started {
+		close(s.rowsQueue)
+		s.workers.Wait()
+		close(s.results)
+		<-s.done
+		s.started = false
+	}
+
+	err := s.getError()
+	if jobErr == nil {
+		jobErr = err
+	}
+
+	finishErr := finishPipeline(s.segment, jobErr)
+	if err == nil {
+		err = finishErr
+	}
+
+	return err
+}
+
+//	Summary report the number of workers, followed by the summaries of the steps they run
+func (s *parallelStep) Summary() string {
+
+	summaryList := []string{fmt.Sprintf("Parallel: %d workers", s.Workers)}
+
+	for step := s.segment; step != nil; step = step.GetNextStep() {
+		summaryStep, ok := step.(DataPipelineStepSummary)
+		if ok {
+			summaryList = append(summaryList, summaryStep.Summary())
+		}
+	}
+
+	return strings.Join(summaryList, "\n")
+}
+
+//	processRows worker that runs the queued rows through the steps
+func (s *parallelStep) processRows() {
+
+	defer s.workers.Done()
+
+	for item := range s.rowsQueue {
+		item.processed, item.err = s.segment.ProcessRow(item.row)
+		s.results <- item
+	}
+}
+
+//	sequenceRows send the processed rows to the next step in the order they were queued
+func (s *parallelStep) sequenceRows() {
+
+	defer close(s.done)
+
+	pending := make(map[int64]*parallelRow)
+	var next int64
+
+	for item := range s.results {
+		pending[item.sequence] = item
+
+		for {
+			item, found := pending[next]
+			if !found {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			s.emitRow(item)
+		}
+	}
+}
+
+//	emitRow send a processed row to the next step, or handle its error like the input sources do; after a fatal
+//	error the remaining rows are discarded
+func (s *parallelStep) emitRow(item *parallelRow) {
+
+	defer func() {
+		s.mutex.Lock()
+		s.emitted++
+		s.drained.Broadcast()
+		s.mutex.Unlock()
+	}()
+
+	if s.getError() != nil {
+		return
+	}
+
+	err := item.err
+	if err == nil && item.processed && s.NextStep != nil {
+		_, err = s.NextStep.ProcessRow(item.row)
+	}
+
+	if err != nil {
+		err = rowError(s.rejecter, item.row.Source, item.row.LineNumber, []byte(item.row.Record), err)
+		if err != nil {
+			s.mutex.Lock()
+			s.err = err
+			s.mutex.Unlock()
+		}
+	}
+}
+
+//	drain wait until every row queued so far leaves the pool
+func (s *parallelStep) drain() {
+
+	if !s.started {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for s.emitted < s.sequence {
+		s.drained.Wait()
+	}
+}
+
+//	getError get the fatal error that stopped the step
+func (s *parallelStep) getError() error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.err
+}
@@ -0,0 +1,267 @@
+///////////////////////////////////////////////////////////////////////////////
+//	parallelStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the parallel pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//	test step that can run concurrently and stops the import at a given id
+type fatalIdStep struct {
+	id int64
+
+	NextStep DataPipelineStep
+}
+
+func (s *fatalIdStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+func (s *fatalIdStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+func (s *fatalIdStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	if row.Get("id") == s.id {
+		return false, NewFatalError(errors.New("fatal id"))
+	}
+
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+func (s *fatalIdStep) ConcurrentSafe() bool {
+	return true
+}
+
+//	Test_ParallelStep_ProcessRow test cases for the parallel step
+func Test_ParallelStep_ProcessRow(t *testing.T) {
+
+	const testFileName = "testData.txt"
+	const rows = 1000
+
+	//	every 3rd row is inactive and every 10th row has an invalid status
+	var data strings.Builder
+	for i := 1; i <= rows; i++ {
+		status := "A"
+		if i%10 == 0 {
+			status = "X"
+		} else if i%3 == 0 {
+			status = "I"
+		}
+		data.WriteString(strconv.Itoa(i) + "," + status + "\n")
+	}
+
+	err := os.WriteFile(testFileName, []byte(data.String()), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove(testFileName)
+
+	t.Run(">>> validation of rows order and rejects", func(t *testing.T) {
+
+		const config = `
+jobs:
+  - name: test
+    workers: 4
+    input:
+      type: csv
+      file_name: testData.txt
+      field_separator: ","
+      fields:
+        - name: id
+          type: integer
+        - name: status
+          type: string
+          allowed_values: [ A, I ]
+    steps:
+      - type: filter
+        condition: status = 'A'
+      - type: derive
+        fields:
+          - name: double
+            type: integer
+            expression: id * 2
+`
+		dmig, err := LoadConfigFile(bufio.NewReader(strings.NewReader(config)))
+		if err != nil {
+			t.Errorf("unexpected error in LoadConfigFile(): %s", err)
+		}
+
+		collector := &rowCollector{}
+		rejects := &rowRejecterMock{}
+		job := &JobContext{Job: &dmig.JobList[0], Messages: &bytes.Buffer{}, Rejecter: rejects}
+
+		firstStep, err := buildPipeline(job, nil)
+		if err != nil {
+			t.Errorf("unexpected error in buildPipeline(): %s", err)
+		}
+
+		parallel, isParallel := firstStep.(*parallelStep)
+		if !isParallel || parallel.GetNextStep() != nil {
+			t.Errorf("fail in buildPipeline(): expected a single parallel step, result: %T", firstStep)
+			return
+		}
+		parallel.SetNextStep(collector)
+
+		err = startPipeline(firstStep, job)
+		if err != nil {
+			t.Errorf("unexpected error in startPipeline(): %s", err)
+		}
+
+		_, err = NewCSVInputFile(dmig.JobList[0].Input).ImportData(context.Background(), firstStep)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		err = finishPipeline(firstStep, nil)
+		if err != nil {
+			t.Errorf("unexpected error in finishPipeline(): %s", err)
+		}
+
+		//	the kept rows must come out in the input order, with their derived values
+		var want, got []string
+		var wantRejects []int64
+		for i := int64(1); i <= rows; i++ {
+			if i%10 == 0 {
+				wantRejects = append(wantRejects, i)
+			} else if i%3 != 0 {
+				want = append(want, fmt.Sprintf("%d:%d", i, i*2))
+			}
+		}
+		for _, row := range collector.rows {
+			got = append(got, row.GetString("id")+":"+row.GetString("double"))
+		}
+
+		if fmt.Sprint(want) != fmt.Sprint(got) {
+			t.Errorf("fail in ProcessRow(): rows out of order: %v", got)
+		}
+		if fmt.Sprint(wantRejects) != fmt.Sprint(rejects.lineList) {
+			t.Errorf("fail in ProcessRow(): expected rejects: %v result: %v", wantRejects, rejects.lineList)
+		}
+
+		wantSummary := "Parallel: 4 workers\nField validation: 900 rows valid, 100 rows invalid\n" +
+			"Filter status = 'A': 600 rows kept, 300 rows dropped"
+		if gotSummary := parallel.Summary(); wantSummary != gotSummary {
+			t.Errorf("fail in Summary(): expected: %s result: %s", wantSummary, gotSummary)
+		}
+	})
+
+	t.Run(">>> validation of fatal error in a worker", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		step, err := NewParallelStep(3, &fatalIdStep{id: 500})
+		if err != nil {
+			t.Errorf("unexpected error in NewParallelStep(): %s", err)
+		}
+		step.SetNextStep(collector)
+
+		err = startPipeline(step, nil)
+		if err != nil {
+			t.Errorf("unexpected error in startPipeline(): %s", err)
+		}
+
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       testFileName,
+			FieldSeparator: ",",
+			FieldList: []DataField{
+				{Name: "id", Type: "integer"},
+				{Name: "status", Type: "string"},
+			},
+		})
+
+		_, importErr := testDataSource.ImportData(context.Background(), step)
+		err = finishPipeline(step, importErr)
+
+		want := "testData.txt, line 500: fatal id"
+		if err == nil || err.Error() != want {
+			t.Errorf("fail in Finish(): expected: %s result: %v", want, err)
+		}
+		if importErr != nil && importErr.Error() != want {
+			t.Errorf("fail in ImportData(): expected: %s result: %v", want, importErr)
+		}
+		if len(collector.rows) != 499 || collector.rows[498].Get("id") != int64(499) {
+			t.Errorf("fail in ProcessRow(): expected 499 rows before the fatal error, result: %d", len(collector.rows))
+		}
+	})
+
+	t.Run(">>> validation of error rate with workers", func(t *testing.T) {
+
+		const testScriptName = "testScript.sql"
+
+		//	every 3rd and every 10th row are rejected: 400 rows
+		const config = `
+jobs:
+  - name: test
+    workers: 4
+    input:
+      type: CSVFile
+      file_name: testData.txt
+      field_separator: ","
+      fields:
+        - name: id
+          type: integer
+        - name: status
+          type: string
+          allowed_values: [ A ]
+    output:
+      type: SQLScript
+      file_name: testScript.sql
+      dialect: postgres
+      table_name: test
+    reject:
+      max_error_percent: 10
+`
+		defer os.Remove(testScriptName)
+
+		dmig, err := LoadConfigFile(bufio.NewReader(strings.NewReader(config)))
+		if err != nil {
+			t.Errorf("unexpected error in LoadConfigFile(): %s", err)
+			return
+		}
+
+		got := ""
+		want := "Job aborted: 40.00% rows rejected, more than 10%"
+
+		err = dmig.performJob(context.Background(), &dmig.JobList[0], &bytes.Buffer{})
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in performJob(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of steps that can't run in parallel", func(t *testing.T) {
+
+		got := ""
+		want := "Pipeline step can't run in parallel: *migration.rowCollector"
+
+		_, err := NewParallelStep(2, &rowCollector{})
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in NewParallelStep(): expected: %s result: %s", want, got)
+		}
+	})
+}
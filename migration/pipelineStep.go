@@ -6,9 +6,90 @@
 
 package migration
 
+import (
+	"context"
+	"errors"
+	"io"
+)
+
 type DataPipelineStep interface {
 	SetNextStep(nextStep DataPipelineStep)
 	GetNextStep() DataPipelineStep
 
-	ProcessRow(row map[string]string) (rowProcessed bool, err error)
+	ProcessRow(row *Row) (rowProcessed bool, err error)
+}
+
+//	pipeline steps that report a summary at the end of the job
+type DataPipelineStepSummary interface {
+	Summary() string
+}
+
+//	pipeline steps with a lifecycle: Start is called before the first row, and Finish after the last one,
+//	with the job's error when it failed; steps that hold rows send them to the next step when Finish has no error
+type DataPipelineStepLifecycle interface {
+	Start(job *JobContext) error
+	Finish(jobErr error) error
+}
+
+//	pipeline steps that process each row on its own, without changing the step's state, so they can process
+//	rows from several goroutines at the same time
+type DataPipelineStepConcurrent interface {
+	ConcurrentSafe() bool
+}
+
+//	attributes of the job a pipeline step is created for
+type JobContext struct {
+	Job       *MigrationJob
+	Messages  io.Writer
+	Rejecter  RowRejecter
+	Resume    *CheckpointState
+	Resumable bool
+	Context   context.Context
+}
+
+//	contextOf get the context of the job, so steps can stop reading their inputs when the job is interrupted
+func contextOf(job *JobContext) context.Context {
+
+	if job == nil || job.Context == nil {
+		return context.Background()
+	}
+
+	return job.Context
+}
+
+//	checkInterrupted get the error that stops the steps sending the rows they keep once the job is interrupted
+func checkInterrupted(ctx context.Context) error {
+
+	if ctx != nil && ctx.Err() != nil {
+		return NewFatalError(jobInterrupted(ctx))
+	}
+
+	return nil
+}
+
+//	errors returned by pipeline steps are row level errors: the row is rejected and the job goes on;
+//	a FatalError stops the job
+type FatalError struct {
+	Err error
+}
+
+func (e *FatalError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FatalError) Unwrap() error {
+	return e.Err
+}
+
+//	NewFatalError create an error that stops the job
+func NewFatalError(err error) error {
+	return &FatalError{Err: err}
+}
+
+//	isFatalError check if an error stops the job
+func isFatalError(err error) bool {
+
+	var fatalErr *FatalError
+
+	return errors.As(err, &fatalErr)
 }
@@ -0,0 +1,278 @@
+///////////////////////////////////////////////////////////////////////////////
+//	rejectFile.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for the file where a job's rejected rows are written
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+//	input sources hand the rows the pipeline failed to process to a RowRejecter, with the original record
+type RowRejecter interface {
+	RejectRow(source string, lineNumber int64, record string, err error) error
+}
+
+//	rejecter for the inputs a step loads on its own, like lookup references and join secondary inputs: an invalid
+//	record fails the step, as skipping it would silently change the step's results
+type failingRejecter struct{}
+
+func (r failingRejecter) RejectRow(source string, lineNumber int64, record string, err error) error {
+	return NewFatalError(err)
+}
+
+//	attributes for a reject file
+type rejectFile struct {
+	FileName        string
+	Compression     string
+	MaxErrors       *int64
+	MaxErrorPercent *float64
+
+	file         io.WriteCloser
+	writer       *csv.Writer
+	rowsRejected int64
+	resumeSize   int64
+	resuming     bool
+	resumable    bool
+	mutex        sync.Mutex
+}
+
+//	NewRejectFile create a new rejectFile; with no file name, rejected rows are only counted
+func NewRejectFile(config JobReject) *rejectFile {
+
+	return &rejectFile{
+		FileName:        config.FileName,
+		Compression:     config.Compression,
+		MaxErrors:       config.MaxErrors,
+		MaxErrorPercent: config.MaxErrorPercent,
+	}
+}
+
+//	ValidateFormat validate the reject file configuration
+func (r *rejectFile) ValidateFormat() error {
+
+	if r.MaxErrors != nil && *r.MaxErrors < 0 {
+		return errors.New("Invalid max errors: " + strconv.FormatInt(*r.MaxErrors, 10))
+	}
+
+	if r.MaxErrorPercent != nil && (*r.MaxErrorPercent < 0 || *r.MaxErrorPercent > 100) {
+		return errors.New("Invalid max error percent: " + strconv.FormatFloat(*r.MaxErrorPercent, 'f', -1, 64))
+	}
+
+	if len(r.FileName) == 0 {
+		return nil
+	}
+
+	return validateOutputCompression(r.FileName, r.Compression)
+}
+
+//	Open create the reject file and write its header; when resuming, the rows rejected up to the checkpoint are kept
+func (r *rejectFile) Open(job *JobContext) error {
+
+	if len(r.FileName) == 0 {
+		return nil
+	}
+
+	r.resumable = job != nil && job.Resumable
+
+	if r.resuming {
+		file, err := resumeOutputFile(r.FileName, r.Compression, r.resumeSize)
+		if err != nil {
+			return errors.New("fail resuming reject file: " + err.Error())
+		}
+
+		r.file = file
+		r.writer = csv.NewWriter(file)
+
+		return nil
+	}
+
+	file, err := createOutputFile(r.FileName, r.Compression)
+	if err != nil {
+		return errors.New("fail creating reject file: " + err.Error())
+	}
+
+	r.file = file
+	r.writer = csv.NewWriter(file)
+
+	return r.writer.Write([]string{"source_file", "line_number", "field", "reason", "record"})
+}
+
+//	Resume set the reject file size and the number of rows rejected up to a checkpoint, before the file is open
+func (r *rejectFile) Resume(size int64, rowsRejected int64) {
+
+	r.resuming = true
+	r.resumeSize = size
+	r.rowsRejected = rowsRejected
+}
+
+//	Checkpoint write the rejected rows and get the reject file size and the number of rows rejected so far
+func (r *rejectFile) Checkpoint() (size int64, rowsRejected int64, err error) {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.writer == nil {
+		return 0, r.rowsRejected, nil
+	}
+
+	r.writer.Flush()
+	err = r.writer.Error()
+	if err == nil {
+		size, err = outputFileSize(r.file)
+	}
+	if err != nil {
+		return 0, 0, errors.New("fail writing reject file: " + err.Error())
+	}
+
+	return size, r.rowsRejected, nil
+}
+
+//	RejectRow write a rejected row to the reject file, aborting the job when there are more than max_errors;
+//	rows can be rejected by the input source and by the workers of a parallel step at the same time
+func (r *rejectFile) RejectRow(source string, lineNumber int64, record string, err error) error {
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.rowsRejected++
+
+	if r.writer != nil {
+		var field string
+		reason := err.Error()
+
+		var validationErr *FieldValidationError
+		if errors.As(err, &validationErr) {
+			field = validationErr.Field
+			reason = validationErr.Reason
+		}
+
+		writeErr := r.writer.Write([]string{source, strconv.FormatInt(lineNumber, 10), field, reason, record})
+		if writeErr != nil {
+			return NewFatalError(errors.New("fail writing reject file: " + writeErr.Error()))
+		}
+	}
+
+	if r.MaxErrors != nil && r.rowsRejected > *r.MaxErrors {
+		return NewFatalError(fmt.Errorf("Job aborted: more than %d rows rejected", *r.MaxErrors))
+	}
+
+	return nil
+}
+
+//	CheckErrorRate check the percentage of rejected rows after all rows are processed
+func (r *rejectFile) CheckErrorRate(rowsProcessed int64) error {
+
+	if r.MaxErrorPercent == nil || rowsProcessed == 0 {
+		return nil
+	}
+
+	errorPercent := 100 * float64(r.rowsRejected) / float64(rowsProcessed)
+	if errorPercent > *r.MaxErrorPercent {
+		return fmt.Errorf("Job aborted: %.2f%% rows rejected, more than %s%%", errorPercent,
+			strconv.FormatFloat(*r.MaxErrorPercent, 'f', -1, 64))
+	}
+
+	return nil
+}
+
+//	Close flush and close the reject file, renaming it into place; the rows rejected by a failed job are kept,
+//	as they may explain why it failed
+func (r *rejectFile) Close() error {
+
+	if r.writer == nil {
+		return nil
+	}
+
+	r.writer.Flush()
+	err := r.writer.Error()
+
+	closeErr := r.file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	r.writer = nil
+	r.file = nil
+
+	if err != nil {
+		if !r.resumable {
+			discardOutputFile(r.FileName)
+		}
+		return errors.New("fail writing reject file: " + err.Error())
+	}
+
+	err = commitOutputFile(r.FileName, false)
+	if err != nil {
+		return errors.New("fail writing reject file: " + err.Error())
+	}
+
+	return nil
+}
+
+//	RowsRejected get the number of rejected rows
+func (r *rejectFile) RowsRejected() int64 {
+	return r.rowsRejected
+}
+
+//	error that stops the import, with the line of the row where it happened
+type RowError struct {
+	Source     string
+	LineNumber int64
+	Err        error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("%s, line %d: %s", e.Source, e.LineNumber, e.Err.Error())
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+//	rowError handle an error processing a row: fatal errors stop the import, with the line where they happened;
+//	other errors reject the row, or skip it when there's no rejecter
+func rowError(rejecter RowRejecter, source string, lineNumber int64, record []byte, err error) error {
+
+	//	errors already handled for a previous row keep that row's line
+	var lineErr *RowError
+	if errors.As(err, &lineErr) {
+		return err
+	}
+
+	if !isFatalError(err) {
+		if rejecter == nil {
+			return nil
+		}
+
+		err = rejecter.RejectRow(source, lineNumber, string(record), err)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return &RowError{Source: source, LineNumber: lineNumber, Err: err}
+}
+
+//	forwardRow send a row a step emits on its own, like the rows sent when it finishes, to the next step; a row level
+//	error rejects the emitted row, so only fatal errors stop the job
+func forwardRow(nextStep DataPipelineStep, rejecter RowRejecter, row *Row) error {
+
+	if nextStep == nil {
+		return nil
+	}
+
+	_, err := nextStep.ProcessRow(row)
+	if err != nil {
+		return rowError(rejecter, row.Source, row.LineNumber, []byte(row.Record), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+///////////////////////////////////////////////////////////////////////////////
+//	rejectFile_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the reject file
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+//	Test_RejectFile_RejectRow test cases for rows rejected while importing data
+func Test_RejectFile_RejectRow(t *testing.T) {
+
+	const testFileName = "testData.txt"
+	const rejectFileName = "testRejects.csv"
+
+	err := os.WriteFile(testFileName, []byte("1,ANN\n2,\nX,BOB\n4,CARL\n"), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove(testFileName)
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer", Required: true},
+		{Name: "name", Type: "string", Required: true},
+	}
+
+	maxErrors := int64(2)
+	noErrors := int64(0)
+	halfErrors := 50.0
+	maxErrorPercent := 40.0
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		config   JobReject
+		output   string
+		rejects  string
+	}{
+		{scenario: "reject file", config: JobReject{FileName: rejectFileName, MaxErrors: &maxErrors, MaxErrorPercent: &halfErrors},
+			output: "rows processed: 4, rows rejected: 2",
+			rejects: "source_file,line_number,field,reason,record\n" +
+				"testData.txt,2,name,required value missing,\"2,\"\n" +
+				"testData.txt,3,id,not an integer: X,\"X,BOB\"\n"},
+		{scenario: "max errors exceeded", config: JobReject{FileName: rejectFileName, MaxErrors: &noErrors},
+			output: "testData.txt, line 2: Job aborted: more than 0 rows rejected",
+			rejects: "source_file,line_number,field,reason,record\n" +
+				"testData.txt,2,name,required value missing,\"2,\"\n"},
+		{scenario: "max error percent exceeded", config: JobReject{MaxErrorPercent: &maxErrorPercent},
+			output: "Job aborted: 50.00% rows rejected, more than 40%"},
+	}
+
+	t.Run(">>> validation of rejected rows", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			validationStep, err := NewFieldValidationStep(fieldList)
+			if err != nil {
+				t.Errorf("unexpected error in NewFieldValidationStep(): %s", err)
+				continue
+			}
+
+			testDataSource := NewCSVInputFile(JobInput{FileName: testFileName, FieldSeparator: ",", FieldList: fieldList})
+
+			rejects := NewRejectFile(test.config)
+			testDataSource.SetRowRejecter(rejects)
+
+			err = rejects.Open(nil)
+			if err != nil {
+				t.Errorf("unexpected error in Open(): %s", err)
+			}
+
+			rowsProcessed, err := testDataSource.ImportData(context.Background(), validationStep)
+			if err == nil {
+				err = rejects.CheckErrorRate(rowsProcessed)
+			}
+			rejects.Close()
+
+			got := ""
+			want := test.output
+
+			if err != nil {
+				got = err.Error()
+			} else {
+				got = fmt.Sprintf("rows processed: %d, rows rejected: %d", rowsProcessed, rejects.RowsRejected())
+			}
+
+			if want != got {
+				t.Errorf("fail in ImportData(): expected: %s result: %s", want, got)
+			}
+
+			if len(test.config.FileName) > 0 {
+				rejectData, err := os.ReadFile(rejectFileName)
+				if err != nil {
+					t.Errorf("unexpected error reading reject file: %s", err)
+				}
+				os.Remove(rejectFileName)
+
+				if test.rejects != string(rejectData) {
+					t.Errorf("fail in RejectRow(): expected: %s result: %s", test.rejects, string(rejectData))
+				}
+			}
+		}
+	})
+
+	t.Run(">>> validation of short fixed position lines", func(t *testing.T) {
+
+		err := os.WriteFile(testFileName, []byte("001ANN\n02\n003CARL\n"), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+
+		testDataSource := NewFixedPositionInputFile(JobInput{FileName: testFileName, FieldList: []DataField{
+			{Name: "id", Type: "integer", StartPosition: 1, EndPosition: 3},
+			{Name: "name", Type: "string", StartPosition: 4, EndPosition: 6},
+		}})
+
+		rejects := NewRejectFile(JobReject{})
+		testDataSource.SetRowRejecter(rejects)
+
+		collector := &rowCollector{}
+
+		_, err = testDataSource.ImportData(context.Background(), collector)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		got := fmt.Sprintf("rows: %d, rows rejected: %d", len(collector.rows), rejects.RowsRejected())
+		want := "rows: 2, rows rejected: 1"
+
+		if want != got {
+			t.Errorf("fail in ImportData(): expected: %s result: %s", want, got)
+		}
+	})
+}
+
+//	test step that fails with a fatal error on a given line
+type fatalRowStep struct {
+	lineNumber int64
+}
+
+func (s *fatalRowStep) SetNextStep(nextStep DataPipelineStep) {
+}
+
+func (s *fatalRowStep) GetNextStep() DataPipelineStep {
+	return nil
+}
+
+func (s *fatalRowStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	if row.LineNumber == s.lineNumber {
+		return false, NewFatalError(errors.New("fail writing row"))
+	}
+
+	return true, nil
+}
+
+//	Test_RejectFile_FatalErrors test cases for errors that stop the import
+func Test_RejectFile_FatalErrors(t *testing.T) {
+
+	const testFileName = "testData.txt"
+	const testDirName = "testData.dir"
+
+	err := os.WriteFile(testFileName, []byte("1,ANN\n2,BOB\n3,CARL\n"), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove(testFileName)
+
+	err = os.Mkdir(testDirName, 0755)
+	if err != nil {
+		t.Errorf("unexpected error creating test directory: %s", err)
+	}
+	defer os.Remove(testDirName)
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "string"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		fileName string
+		output   string
+	}{
+		{scenario: "fatal pipeline error", fileName: testFileName,
+			output: "testData.txt, line 2: fail writing row"},
+		{scenario: "read error", fileName: testDirName,
+			output: "fail reading testData.dir: read testData.dir: is a directory"},
+	}
+
+	t.Run(">>> validation of fatal errors", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			testDataSource := NewCSVInputFile(JobInput{FileName: test.fileName, FieldSeparator: ",", FieldList: fieldList})
+			testDataSource.SetRowRejecter(NewRejectFile(JobReject{}))
+
+			got := ""
+			want := test.output
+
+			_, err := testDataSource.ImportData(context.Background(), &fatalRowStep{lineNumber: 2})
+			if err != nil {
+				got = err.Error()
+			}
+
+			if want != got {
+				t.Errorf("fail in ImportData(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_RejectFile_FinishedRows test cases for rows rejected after the steps that keep them finish
+func Test_RejectFile_FinishedRows(t *testing.T) {
+
+	const testFileName = "testData.txt"
+	const testScriptName = "testScript.sql"
+	const testRejectName = "testReject.csv"
+
+	//	the sorted rows are sent to the derive step when the sort step finishes; every 3rd row is invalid
+	const config = `
+jobs:
+  - name: test
+    input:
+      type: CSVFile
+      file_name: testData.txt
+      field_separator: ","
+      fields:
+        - name: id
+          type: integer
+    steps:
+      - type: sort
+        fields:
+          - name: id
+            order: desc
+      - type: derive
+        fields:
+          - name: q
+            type: integer
+            expression: if(id % 3 = 0, 0.5, id)
+    output:
+      type: SQLScript
+      file_name: testScript.sql
+      dialect: postgres
+      table_name: test
+    reject:
+      file_name: testReject.csv
+`
+	var input strings.Builder
+	for i := 1; i <= 10; i++ {
+		fmt.Fprintf(&input, "%d\n", i)
+	}
+
+	err := os.WriteFile(testFileName, []byte(input.String()), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove(testFileName)
+	defer os.Remove(testScriptName)
+	defer os.Remove(testRejectName)
+
+	maxErrorPercent := float64(20)
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario        string
+		maxErrorPercent *float64
+		output          string
+	}{
+		{scenario: "rejected sorted rows", output: "source_file,line_number,field,reason,record\ntestData.txt,9,,Invalid integer value for field q: 0.5,9\n" +
+			"testData.txt,6,,Invalid integer value for field q: 0.5,6\ntestData.txt,3,,Invalid integer value for field q: 0.5,3\n"},
+		{scenario: "error rate with rejected sorted rows", maxErrorPercent: &maxErrorPercent,
+			output: "Job aborted: 30.00% rows rejected, more than 20%"},
+	}
+
+	t.Run(">>> validation of rows rejected when the steps finish", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			dmig, err := LoadConfigFile(bufio.NewReader(strings.NewReader(config)))
+			if err != nil {
+				t.Errorf("unexpected error in LoadConfigFile(): %s", err)
+				return
+			}
+			dmig.JobList[0].Reject.MaxErrorPercent = test.maxErrorPercent
+
+			got := ""
+			want := test.output
+
+			err = dmig.performJob(context.Background(), &dmig.JobList[0], &bytes.Buffer{})
+			if err != nil {
+				got = err.Error()
+			} else {
+				data, _ := os.ReadFile(testRejectName)
+				got = string(data)
+			}
+
+			if want != got {
+				t.Errorf("fail in performJob(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,215 @@
+///////////////////////////////////////////////////////////////////////////////
+//	row.go  -  Oct-19-2026  -  aldebap
+//
+//	Data row carried through the pipeline
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//	time and decimal values are written by gob as part of the rows
+func init() {
+	gob.Register(time.Time{})
+	gob.Register(Decimal{})
+}
+
+//	attributes of a data row: its fields, in the order they were set, and where the row came from;
+//	field values are typed: nil (null), string, int64, Decimal, time.Time or bool
+type Row struct {
+	Source     string
+	LineNumber int64
+	Record     string
+
+	fieldList []string
+	valueList map[string]interface{}
+}
+
+//	attributes of a row as written by gob
+type rowRecord struct {
+	Source     string
+	LineNumber int64
+	Record     string
+	FieldList  []string
+	ValueList  []interface{}
+}
+
+//	NewRow create a new empty row
+func NewRow() *Row {
+
+	return &Row{
+		valueList: make(map[string]interface{}),
+	}
+}
+
+//	Fields get the names of the row's fields, in order, without the metadata fields
+func (r *Row) Fields() []string {
+	return r.fieldList
+}
+
+//	Has check if the row has a field; the metadata fields are always present
+func (r *Row) Has(name string) bool {
+
+	if name == ROW_SOURCE_FILE || name == ROW_LINE_NUMBER {
+		return true
+	}
+
+	_, found := r.valueList[name]
+
+	return found
+}
+
+//	Get get a field value, or nil when the field is missing; metadata fields can also be read by name
+func (r *Row) Get(name string) interface{} {
+
+	switch name {
+	case ROW_SOURCE_FILE:
+		return r.Source
+
+	case ROW_LINE_NUMBER:
+		return r.LineNumber
+	}
+
+	return r.valueList[name]
+}
+
+//	GetString get a field value as string; null is an empty string
+func (r *Row) GetString(name string) string {
+	return exprString(r.Get(name))
+}
+
+//	Set set a field value; new fields go to the end of the row
+func (r *Row) Set(name string, value interface{}) {
+
+	if r.valueList == nil {
+		r.valueList = make(map[string]interface{})
+	}
+
+	_, found := r.valueList[name]
+	if !found {
+		r.fieldList = append(r.fieldList, name)
+	}
+
+	//	int values are kept as int64, like the integer fields read from the inputs,
+	//	and float values are kept as exact decimals
+	switch number := value.(type) {
+	case int:
+		value = int64(number)
+
+	case float64:
+		value = decimalFromFloat(number)
+	}
+
+	r.valueList[name] = value
+}
+
+//	Reset remove all the row's fields, so the row can be reused
+func (r *Row) Reset() {
+
+	r.Source = ""
+	r.LineNumber = 0
+	r.Record = ""
+	r.fieldList = r.fieldList[:0]
+
+	for name := range r.valueList {
+		delete(r.valueList, name)
+	}
+}
+
+//	Copy copy the row: input sources reuse the same row between calls, so steps that keep rows need a copy
+func (r *Row) Copy() *Row {
+
+	rowCopy := &Row{
+		Source:     r.Source,
+		LineNumber: r.LineNumber,
+		Record:     r.Record,
+		fieldList:  make([]string, len(r.fieldList)),
+		valueList:  make(map[string]interface{}, len(r.valueList)),
+	}
+
+	copy(rowCopy.fieldList, r.fieldList)
+	for name, value := range r.valueList {
+		rowCopy.valueList[name] = value
+	}
+
+	return rowCopy
+}
+
+//	String format the row's fields in order, followed by the metadata fields; nulls are shown as NULL, without quotes
+func (r *Row) String() string {
+
+	var fieldList []string
+
+	for _, name := range r.fieldList {
+		value := r.valueList[name]
+		if value == nil {
+			fieldList = append(fieldList, name+" = NULL")
+			continue
+		}
+
+		fieldList = append(fieldList, fmt.Sprintf("%s = '%s'", name, exprString(value)))
+	}
+	fieldList = append(fieldList, fmt.Sprintf("%s = '%s'", ROW_SOURCE_FILE, r.Source))
+	fieldList = append(fieldList, fmt.Sprintf("%s = '%s'", ROW_LINE_NUMBER, strconv.FormatInt(r.LineNumber, 10)))
+
+	return strings.Join(fieldList, "; ")
+}
+
+//	GobEncode encode the row for gob, so rows can be written to temporary files
+func (r *Row) GobEncode() ([]byte, error) {
+
+	record := rowRecord{
+		Source:     r.Source,
+		LineNumber: r.LineNumber,
+		Record:     r.Record,
+		FieldList:  r.fieldList,
+	}
+
+	for _, name := range r.fieldList {
+		record.ValueList = append(record.ValueList, r.valueList[name])
+	}
+
+	var buffer bytes.Buffer
+
+	err := gob.NewEncoder(&buffer).Encode(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+//	GobDecode decode a row written by GobEncode
+func (r *Row) GobDecode(data []byte) error {
+
+	var record rowRecord
+
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&record)
+	if err != nil {
+		return err
+	}
+
+	if len(record.ValueList) != len(record.FieldList) {
+		return errors.New("Invalid row record: " + strconv.Itoa(len(record.FieldList)) + " fields, " +
+			strconv.Itoa(len(record.ValueList)) + " values")
+	}
+
+	r.Reset()
+	r.Source = record.Source
+	r.LineNumber = record.LineNumber
+	r.Record = record.Record
+
+	for i, name := range record.FieldList {
+		r.Set(name, record.ValueList[i])
+	}
+
+	return nil
+}
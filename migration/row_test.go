@@ -0,0 +1,209 @@
+///////////////////////////////////////////////////////////////////////////////
+//	row_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the data row
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+//	Test_Row_Fields test cases for the row fields and values
+func Test_Row_Fields(t *testing.T) {
+
+	t.Run(">>> validation of fields order and trace format", func(t *testing.T) {
+
+		row := NewRow()
+		row.Source = "input.txt"
+		row.LineNumber = 7
+		row.Set("name", "Ann")
+		row.Set("id", 10)
+		row.Set("rate", 1.5)
+		row.Set("name", "Bob")
+		row.Set("active", true)
+		row.Set("since", time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC))
+		row.Set("email", nil)
+
+		got := row.String()
+		want := "name = 'Bob'; id = '10'; rate = '1.5'; active = 'true'; since = '2023-01-31'; email = NULL; " +
+			"_source_file = 'input.txt'; _line_number = '7'"
+
+		if want != got {
+			t.Errorf("fail in String(): expected: %s result: %s", want, got)
+		}
+
+		if _, isInteger := row.Get("id").(int64); !isInteger {
+			t.Errorf("fail in Set(): expected int64 value, result: %T", row.Get("id"))
+		}
+		if row.Get(ROW_LINE_NUMBER) != int64(7) || !row.Has(ROW_SOURCE_FILE) || row.Has("xpto") {
+			t.Errorf("fail in Get(): unexpected metadata fields: %v", row)
+		}
+	})
+
+	t.Run(">>> validation of row copy and reset", func(t *testing.T) {
+
+		row := newTestRow(map[string]string{"id": "1", "name": "Ann"})
+		rowCopy := row.Copy()
+
+		row.Reset()
+		row.Set("other", "x")
+
+		got := row.String() + " | " + rowCopy.String()
+		want := "other = 'x'; _source_file = ''; _line_number = '0' | " +
+			"id = '1'; name = 'Ann'; _source_file = ''; _line_number = '0'"
+
+		if want != got {
+			t.Errorf("fail in Copy(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of gob encoding", func(t *testing.T) {
+
+		row := NewRow()
+		row.Source = "input.txt"
+		row.LineNumber = 3
+		row.Set("id", int64(10))
+		row.Set("name", "Ann")
+		row.Set("email", nil)
+		row.Set("since", time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC))
+		row.Set("amount", decimalValue("10.50"))
+
+		var buffer bytes.Buffer
+
+		err := gob.NewEncoder(&buffer).Encode(row)
+		if err != nil {
+			t.Errorf("unexpected error in GobEncode(): %s", err)
+		}
+
+		decoded := NewRow()
+
+		err = gob.NewDecoder(&buffer).Decode(decoded)
+		if err != nil {
+			t.Errorf("unexpected error in GobDecode(): %s", err)
+		}
+
+		if want, got := row.String(), decoded.String(); want != got {
+			t.Errorf("fail in GobDecode(): expected: %s result: %s", want, got)
+		}
+		if decoded.Get("id") != int64(10) || decoded.Get("email") != nil {
+			t.Errorf("fail in GobDecode(): values not typed: %T %T", decoded.Get("id"), decoded.Get("email"))
+		}
+		if _, isDecimal := decoded.Get("amount").(Decimal); !isDecimal {
+			t.Errorf("fail in GobDecode(): expected Decimal value, result: %T", decoded.Get("amount"))
+		}
+	})
+}
+
+//	Test_Row_InputTypes test cases for the typed values read from the input files
+func Test_Row_InputTypes(t *testing.T) {
+
+	const testFileName = "testData.txt"
+
+	err := os.WriteFile(testFileName, []byte("1,Ann\n,Bob\nX,Carl\n"), 0644)
+	if err != nil {
+		t.Errorf("unexpected error creating test file: %s", err)
+	}
+	defer os.Remove(testFileName)
+
+	t.Run(">>> validation of input values types", func(t *testing.T) {
+
+		collector := &rowCollector{}
+		rejects := &rowRejecterMock{}
+
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       testFileName,
+			FieldSeparator: ",",
+			FieldList: []DataField{
+				{Name: "id", Type: "integer"},
+				{Name: "name", Type: "string"},
+			},
+		})
+		testDataSource.SetRowRejecter(rejects)
+
+		_, err := testDataSource.ImportData(context.Background(), collector)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		var got []string
+		for _, row := range collector.rows {
+			got = append(got, fmt.Sprintf("%T:%T", row.Get("id"), row.Get("name")))
+		}
+		got = append(got, rejects.errList...)
+
+		want := "[int64:string <nil>:string Invalid value for field id: not an integer: X]"
+
+		if want != fmt.Sprint(got) {
+			t.Errorf("fail in ImportData(): expected: %s result: %v", want, got)
+		}
+	})
+
+	t.Run(">>> validation of decimal, date and boolean values", func(t *testing.T) {
+
+		err := os.WriteFile(testFileName, []byte("10.50,19/10/2026,2026-10-19 08:30:00,true\n"+
+			"1.5x,19/10/2026,2026-10-19 08:30:00,false\n3,2026-10-19,2026-10-19 08:30:00,false\n"+
+			",,,\n7,01/02/2026,2026-10-19,yes\n"), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+
+		collector := &rowCollector{}
+		rejects := &rowRejecterMock{}
+
+		testDataSource := NewCSVInputFile(JobInput{
+			FileName:       testFileName,
+			FieldSeparator: ",",
+			FieldList: []DataField{
+				{Name: "amount", Type: "decimal"},
+				{Name: "day", Type: "date", Format: "DD/MM/YYYY"},
+				{Name: "updated", Type: "timestamp"},
+				{Name: "active", Type: "boolean"},
+			},
+		})
+		testDataSource.SetRowRejecter(rejects)
+
+		_, err = testDataSource.ImportData(context.Background(), collector)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		var got []string
+		for _, row := range collector.rows {
+			got = append(got, fmt.Sprintf("%T:%T:%T:%T %s,%s,%s,%s", row.Get("amount"), row.Get("day"), row.Get("updated"),
+				row.Get("active"), row.GetString("amount"), row.GetString("day"), row.GetString("updated"), row.GetString("active")))
+		}
+		got = append(got, rejects.errList...)
+
+		want := "[migration.Decimal:time.Time:time.Time:bool 10.50,2026-10-19,2026-10-19 08:30:00,true " +
+			"<nil>:<nil>:<nil>:<nil> ,,, " +
+			"Invalid value for field amount: not a decimal: 1.5x " +
+			"Invalid value for field day: not a date: 2026-10-19 " +
+			"Invalid value for field updated: not a timestamp: 2026-10-19]"
+
+		if want != fmt.Sprint(got) {
+			t.Errorf("fail in ImportData(): expected: %s result: %v", want, got)
+		}
+	})
+}
+
+//	test rejecter that keeps the errors and line numbers of the rejected rows
+type rowRejecterMock struct {
+	errList  []string
+	lineList []int64
+}
+
+func (r *rowRejecterMock) RejectRow(source string, lineNumber int64, record string, err error) error {
+
+	r.errList = append(r.errList, err.Error())
+	r.lineList = append(r.lineList, lineNumber)
+
+	return nil
+}
@@ -0,0 +1,553 @@
+///////////////////////////////////////////////////////////////////////////////
+//	sortStep.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a pipeline step that sorts rows, spilling sorted runs to temporary files
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//	constants for sort orders
+const (
+	ASCENDING  = 1
+	DESCENDING = 2
+)
+
+var (
+	sort_order = map[string]uint8{
+		"asc":  ASCENDING,
+		"desc": DESCENDING,
+	}
+)
+
+//	constants for null ordering
+const (
+	NULLS_LAST  = 1
+	NULLS_FIRST = 2
+)
+
+var (
+	null_order = map[string]uint8{
+		"last":  NULLS_LAST,
+		"first": NULLS_FIRST,
+	}
+)
+
+//	number of rows kept in memory when none is given
+const defaultSortMemoryRows = 100000
+
+//	attributes for a sort field
+type SortField struct {
+	Name  string `yaml:"name"`
+	Order string `yaml:"order"`
+	Nulls string `yaml:"nulls"`
+}
+
+//	attributes for a sort step configuration
+type SortConfig struct {
+	FieldList  []SortField `yaml:"fields"`
+	MemoryRows int         `yaml:"memory_rows"`
+	TempDir    string      `yaml:"temp_dir"`
+}
+
+//	attributes of a sort field ready for comparisons
+type sortKey struct {
+	name       string
+	fieldType  uint8
+	descending bool
+	nullsFirst bool
+}
+
+//	ordering of rows by a list of sort fields
+type rowOrder []sortKey
+
+//	attributes for a sort step
+type sortStep struct {
+	Config SortConfig
+
+	NextStep DataPipelineStep
+
+	order      rowOrder
+	ctx        context.Context
+	rejecter   RowRejecter
+	rows       []*Row
+	runList    []string
+	rowsSorted int64
+}
+
+//	NewSortStep create a new sortStep, given the sort fields and the input fields
+func NewSortStep(config SortConfig, inputFieldList []DataField) (DataPipelineStep, error) {
+
+	if len(config.FieldList) == 0 {
+		return nil, errors.New("Sort step need at least one field")
+	}
+
+	if config.MemoryRows == 0 {
+		config.MemoryRows = defaultSortMemoryRows
+	}
+	if config.MemoryRows < 0 {
+		return nil, errors.New("Invalid sort memory rows: " + strconv.Itoa(config.MemoryRows))
+	}
+
+	order, err := newRowOrder(config.FieldList, inputFieldList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sortStep{
+		Config: config,
+		order:  order,
+	}, nil
+}
+
+//	newSortStepFromConfig create a new sortStep from a job step configuration
+func newSortStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	var stepConfig SortConfig
+
+	err := config.Decode(&stepConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSortStep(stepConfig, job.Job.Input.FieldList)
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *sortStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *sortStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ProcessRow keep a copy of the row, spilling a sorted run to a temporary file when the memory budget is exceeded
+func (s *sortStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	s.rows = append(s.rows, row.Copy())
+	s.rowsSorted++
+
+	if len(s.rows) >= s.Config.MemoryRows {
+		err = s.spillRun()
+		if err != nil {
+			s.removeRuns()
+			return false, NewFatalError(err)
+		}
+	}
+
+	return true, nil
+}
+
+//	Start nothing to prepare: rows are kept in memory until there are too many of them
+func (s *sortStep) Start(job *JobContext) error {
+
+	s.ctx = contextOf(job)
+
+	s.rejecter = nil
+	if job != nil {
+		s.rejecter = job.Rejecter
+	}
+
+	return nil
+}
+
+//	Finish send the rows to the next step in order, merging the sorted runs, and remove the run files
+func (s *sortStep) Finish(jobErr error) error {
+
+	defer s.removeRuns()
+
+	//	when the job failed, the rows are discarded
+	if jobErr != nil {
+		s.rows = nil
+		return nil
+	}
+
+	s.sortRows()
+
+	//	when everything fits in memory there's nothing to merge
+	if len(s.runList) == 0 {
+		defer func() { s.rows = nil }()
+
+		for _, row := range s.rows {
+			err := checkInterrupted(s.ctx)
+			if err != nil {
+				return err
+			}
+
+			err = s.nextStep(row)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if len(s.rows) > 0 {
+		err := s.spillRun()
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.mergeRuns()
+}
+
+//	Summary report the number of rows sorted and runs spilled to temporary files
+func (s *sortStep) Summary() string {
+
+	var fieldList []string
+
+	for _, field := range s.Config.FieldList {
+		fieldList = append(fieldList, field.Name)
+	}
+
+	return fmt.Sprintf("Sort %s: %d rows sorted, %d runs spilled", strings.Join(fieldList, ", "), s.rowsSorted, len(s.runList))
+}
+
+//	nextStep if available, invoke the next step in the pipeline, rejecting the rows it fails to process
+func (s *sortStep) nextStep(row *Row) error {
+	return forwardRow(s.NextStep, s.rejecter, row)
+}
+
+//	sortRows sort the rows in memory keeping the order of equal rows
+func (s *sortStep) sortRows() {
+
+	sort.SliceStable(s.rows, func(i, j int) bool {
+		return s.order.compare(s.rows[i], s.rows[j]) < 0
+	})
+}
+
+//	newRowOrder create a row ordering from the sort fields and the input fields
+func newRowOrder(fieldList []SortField, inputFieldList []DataField) (rowOrder, error) {
+
+	var order rowOrder
+
+	fieldTypes := make(map[string]uint8)
+	for _, field := range inputFieldList {
+		fieldTypes[field.Name] = data_field_type[field.Type]
+	}
+
+	for _, field := range fieldList {
+		if len(field.Name) == 0 {
+			return nil, errors.New("Missing sort field name")
+		}
+
+		if len(field.Order) == 0 {
+			field.Order = "asc"
+		}
+		sortOrder, found := sort_order[field.Order]
+		if !found {
+			return nil, errors.New("Invalid sort order: " + field.Order)
+		}
+
+		if len(field.Nulls) == 0 {
+			field.Nulls = "last"
+		}
+		nulls, found := null_order[field.Nulls]
+		if !found {
+			return nil, errors.New("Invalid null ordering: " + field.Nulls)
+		}
+
+		order = append(order, sortKey{
+			name:       field.Name,
+			fieldType:  fieldTypes[field.Name],
+			descending: sortOrder == DESCENDING,
+			nullsFirst: nulls == NULLS_FIRST,
+		})
+	}
+
+	return order, nil
+}
+
+//	compare compare two rows by the sort fields
+func (o rowOrder) compare(row1 *Row, row2 *Row) int {
+
+	for _, key := range o {
+		isNull1 := row1.Get(key.name) == nil
+		isNull2 := row2.Get(key.name) == nil
+
+		//	nulls are placed first or last regardless of the sort order
+		if isNull1 || isNull2 {
+			if isNull1 == isNull2 {
+				continue
+			}
+
+			if isNull1 == key.nullsFirst {
+				return -1
+			}
+			return 1
+		}
+
+		result := compareRowValues(row1.Get(key.name), row2.Get(key.name), key.fieldType)
+		if result != 0 {
+			if key.descending {
+				return -result
+			}
+			return result
+		}
+	}
+
+	return 0
+}
+
+//	compareRowValues compare two typed field values: numbers by their value, dates in time order, and
+//	the other values by their text, ignoring padding spaces
+func compareRowValues(value1 interface{}, value2 interface{}, fieldType uint8) int {
+
+	int1, isInt1 := value1.(int64)
+	int2, isInt2 := value2.(int64)
+
+	if isInt1 && isInt2 {
+		switch {
+		case int1 < int2:
+			return -1
+		case int1 > int2:
+			return 1
+		}
+		return 0
+	}
+
+	number1, isNumber1 := exprNumber(value1)
+	number2, isNumber2 := exprNumber(value2)
+
+	if isNumber1 && isNumber2 {
+		return number1.Cmp(number2)
+	}
+
+	time1, isTime1 := value1.(time.Time)
+	time2, isTime2 := value2.(time.Time)
+
+	if isTime1 && isTime2 {
+		switch {
+		case time1.Before(time2):
+			return -1
+		case time1.After(time2):
+			return 1
+		}
+		return 0
+	}
+
+	//	false comes before true
+	bool1, isBool1 := value1.(bool)
+	bool2, isBool2 := value2.(bool)
+
+	if isBool1 && isBool2 {
+		switch {
+		case !bool1 && bool2:
+			return -1
+		case bool1 && !bool2:
+			return 1
+		}
+		return 0
+	}
+
+	return compareValues(strings.TrimSpace(exprString(value1)), strings.TrimSpace(exprString(value2)), fieldType)
+}
+
+//	compareValues compare two values as values of the field's type, when both are valid ones, or by their text
+func compareValues(value1 string, value2 string, fieldType uint8) int {
+
+	if fieldType != STRING && fieldType != 0 {
+		typedValue1, err1 := parseFieldValue(fieldType, "", value1)
+		typedValue2, err2 := parseFieldValue(fieldType, "", value2)
+
+		if err1 == nil && err2 == nil && typedValue1 != nil && typedValue2 != nil {
+			return compareRowValues(typedValue1, typedValue2, fieldType)
+		}
+	}
+
+	return strings.Compare(value1, value2)
+}
+
+//	spillRun sort the rows in memory and write them to a temporary run file
+func (s *sortStep) spillRun() error {
+
+	s.sortRows()
+
+	runFile, err := os.CreateTemp(s.Config.TempDir, "dmig-sort-*.run")
+	if err != nil {
+		return errors.New("fail creating sort run file: " + err.Error())
+	}
+	s.runList = append(s.runList, runFile.Name())
+
+	writer := bufio.NewWriter(runFile)
+	encoder := gob.NewEncoder(writer)
+
+	for _, row := range s.rows {
+		err = encoder.Encode(row)
+		if err != nil {
+			runFile.Close()
+			return errors.New("fail writing sort run file: " + err.Error())
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		runFile.Close()
+		return errors.New("fail writing sort run file: " + err.Error())
+	}
+
+	err = runFile.Close()
+	if err != nil {
+		return errors.New("fail writing sort run file: " + err.Error())
+	}
+
+	s.rows = nil
+
+	return nil
+}
+
+//	removeRuns remove the temporary run files
+func (s *sortStep) removeRuns() {
+
+	for _, runName := range s.runList {
+		os.Remove(runName)
+	}
+}
+
+//	attributes of a run file being merged
+type sortRun struct {
+	file    *os.File
+	decoder *gob.Decoder
+	row     *Row
+	index   int
+}
+
+//	next read the next row from the run file, or nil at the end of the run
+func (r *sortRun) next() error {
+
+	r.row = nil
+
+	row := NewRow()
+
+	err := r.decoder.Decode(row)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return errors.New("fail reading sort run file: " + err.Error())
+	}
+	r.row = row
+
+	return nil
+}
+
+//	heap of runs ordered by their current row; runs created first go first for equal rows, keeping the sort stable
+type sortRunHeap struct {
+	step    *sortStep
+	runList []*sortRun
+}
+
+func (h *sortRunHeap) Len() int {
+	return len(h.runList)
+}
+
+func (h *sortRunHeap) Less(i, j int) bool {
+
+	result := h.step.order.compare(h.runList[i].row, h.runList[j].row)
+	if result == 0 {
+		return h.runList[i].index < h.runList[j].index
+	}
+
+	return result < 0
+}
+
+func (h *sortRunHeap) Swap(i, j int) {
+	h.runList[i], h.runList[j] = h.runList[j], h.runList[i]
+}
+
+func (h *sortRunHeap) Push(x interface{}) {
+	h.runList = append(h.runList, x.(*sortRun))
+}
+
+func (h *sortRunHeap) Pop() interface{} {
+
+	run := h.runList[len(h.runList)-1]
+	h.runList = h.runList[:len(h.runList)-1]
+
+	return run
+}
+
+//	mergeRuns merge the sorted run files sending the rows to the next step
+func (s *sortStep) mergeRuns() error {
+
+	runHeap := &sortRunHeap{step: s}
+
+	defer func() {
+		for _, run := range runHeap.runList {
+			run.file.Close()
+		}
+	}()
+
+	for i, runName := range s.runList {
+		runFile, err := os.Open(runName)
+		if err != nil {
+			return errors.New("fail reading sort run file: " + err.Error())
+		}
+
+		run := &sortRun{
+			file:    runFile,
+			decoder: gob.NewDecoder(bufio.NewReader(runFile)),
+			index:   i,
+		}
+
+		err = run.next()
+		if err != nil {
+			runFile.Close()
+			return err
+		}
+
+		if run.row == nil {
+			runFile.Close()
+			continue
+		}
+		runHeap.runList = append(runHeap.runList, run)
+	}
+
+	heap.Init(runHeap)
+
+	for runHeap.Len() > 0 {
+		run := runHeap.runList[0]
+
+		err := checkInterrupted(s.ctx)
+		if err != nil {
+			return err
+		}
+
+		err = s.nextStep(run.row)
+		if err != nil {
+			return err
+		}
+
+		err = run.next()
+		if err != nil {
+			return err
+		}
+
+		if run.row == nil {
+			run.file.Close()
+			heap.Pop(runHeap)
+		} else {
+			heap.Fix(runHeap, 0)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+///////////////////////////////////////////////////////////////////////////////
+//	sortStep_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the sort pipeline step
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//	Test_SortStep_Flush test cases for the sort step
+func Test_SortStep_Flush(t *testing.T) {
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer"},
+		{Name: "branch", Type: "string", NullValues: []string{""}},
+		{Name: "amount", Type: "integer"},
+	}
+
+	rowList := []map[string]string{
+		{"id": "1", "branch": "SP", "amount": "100"},
+		{"id": "2", "branch": "RJ", "amount": "  9"},
+		{"id": "3", "branch": "SP", "amount": ""},
+		{"id": "4", "branch": "MG", "amount": "100"},
+		{"id": "5", "branch": "RJ", "amount": "25"},
+		{"id": "6", "branch": "", "amount": "7"},
+		{"id": "7", "branch": "SP", "amount": "10"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario   string
+		fieldList  []SortField
+		memoryRows int
+		output     string
+		runs       int
+	}{
+		{scenario: "integer ascending", fieldList: []SortField{{Name: "amount"}},
+			output: "6 2 7 5 1 4 3"},
+		{scenario: "integer descending with nulls first", fieldList: []SortField{{Name: "amount", Order: "desc", Nulls: "first"}},
+			output: "3 1 4 5 7 2 6"},
+		{scenario: "two fields", fieldList: []SortField{{Name: "branch"}, {Name: "amount", Order: "desc"}},
+			output: "4 5 2 1 7 3 6"},
+		{scenario: "two fields spilling runs", fieldList: []SortField{{Name: "branch"}, {Name: "amount", Order: "desc"}}, memoryRows: 2,
+			output: "4 5 2 1 7 3 6", runs: 4},
+		{scenario: "stable sort spilling runs", fieldList: []SortField{{Name: "branch", Nulls: "first"}}, memoryRows: 3,
+			output: "6 4 2 5 1 3 7", runs: 3},
+	}
+
+	tempDir := t.TempDir()
+
+	t.Run(">>> validation of sort step", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			collector := &rowCollector{}
+
+			step, err := NewSortStep(SortConfig{FieldList: test.fieldList, MemoryRows: test.memoryRows, TempDir: tempDir}, fieldList)
+			if err != nil {
+				t.Errorf("unexpected error in NewSortStep(): %s", err)
+				continue
+			}
+			step.SetNextStep(collector)
+
+			for _, row := range rowList {
+				_, err = step.ProcessRow(newInputTestRow(row, fieldList))
+				if err != nil {
+					t.Errorf("unexpected error in ProcessRow(): %s", err)
+				}
+			}
+
+			err = finishPipeline(step, nil)
+			if err != nil {
+				t.Errorf("unexpected error in Finish(): %s", err)
+			}
+
+			var idList []string
+			for _, row := range collector.rows {
+				idList = append(idList, row.GetString("id"))
+			}
+
+			got := strings.Join(idList, " ")
+			if test.output != got {
+				t.Errorf("fail in Finish(): expected: %s result: %s", test.output, got)
+			}
+
+			if len(step.(*sortStep).runList) != test.runs {
+				t.Errorf("fail in ProcessRow(): expected runs: %d result: %d", test.runs, len(step.(*sortStep).runList))
+			}
+
+			//	run files are removed after the merge
+			runFiles, _ := filepath.Glob(filepath.Join(tempDir, "*"))
+			if len(runFiles) != 0 {
+				t.Errorf("fail in Finish(): run files not removed: %v", runFiles)
+			}
+		}
+	})
+
+	t.Run(">>> validation of derived typed values", func(t *testing.T) {
+
+		collector := &rowCollector{}
+
+		//	the derived field isn't an input field, so its values are compared by their type
+		step, err := NewSortStep(SortConfig{FieldList: []SortField{{Name: "derived"}}, MemoryRows: 2, TempDir: tempDir}, fieldList[:1])
+		if err != nil {
+			t.Errorf("unexpected error in NewSortStep(): %s", err)
+			return
+		}
+		step.SetNextStep(collector)
+
+		for _, value := range []interface{}{int64(10), int64(2), decimalValue("2.50"), int64(9), decimalValue("-1.5")} {
+			row := NewRow()
+			row.Set("derived", value)
+
+			_, err = step.ProcessRow(row)
+			if err != nil {
+				t.Errorf("unexpected error in ProcessRow(): %s", err)
+			}
+		}
+
+		err = finishPipeline(step, nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		var valueList []string
+		for _, row := range collector.rows {
+			valueList = append(valueList, row.GetString("derived"))
+		}
+
+		want := "-1.5 2 2.50 9 10"
+		got := strings.Join(valueList, " ")
+
+		if want != got {
+			t.Errorf("fail in Finish(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of interrupted job", func(t *testing.T) {
+
+		for _, memoryRows := range []int{0, 2} {
+
+			fmt.Printf("scenario: interrupted job with %d memory rows\n", memoryRows)
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			//	the job is interrupted once the first sorted row is sent
+			var sorted []string
+			collector := &rowCopyStep{
+				process: func(row *Row) error {
+					sorted = append(sorted, row.GetString("id"))
+					cancel()
+					return nil
+				},
+			}
+
+			step, err := NewSortStep(SortConfig{FieldList: []SortField{{Name: "amount"}}, MemoryRows: memoryRows, TempDir: tempDir}, fieldList)
+			if err != nil {
+				t.Errorf("unexpected error in NewSortStep(): %s", err)
+				continue
+			}
+			step.SetNextStep(collector)
+
+			err = startPipeline(step, &JobContext{Context: ctx})
+			for _, row := range rowList {
+				if err == nil {
+					_, err = step.ProcessRow(newInputTestRow(row, fieldList))
+				}
+			}
+			if err == nil {
+				err = finishPipeline(step, nil)
+			}
+
+			got := fmt.Sprintf("%v %v", sorted, err)
+			want := "[6] Job interrupted: context canceled"
+
+			if want != got || !isFatalError(err) {
+				t.Errorf("fail in Finish(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+
+	t.Run(">>> validation of invalid sort order", func(t *testing.T) {
+
+		got := ""
+		want := "Invalid sort order: up"
+
+		_, err := NewSortStep(SortConfig{FieldList: []SortField{{Name: "id", Order: "up"}}}, fieldList)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in NewSortStep(): expected: %s result: %s", want, got)
+		}
+	})
+}
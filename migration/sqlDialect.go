@@ -0,0 +1,402 @@
+///////////////////////////////////////////////////////////////////////////////
+//	sqlDialect.go  -  Oct-19-2026  -  aldebap
+//
+//	SQL statements generation for the supported database dialects
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//	constants for SQL dialects
+const (
+	POSTGRESQL = 1
+	MYSQL      = 2
+	ORACLE     = 3
+	SQLSERVER  = 4
+	SQLITE     = 5
+)
+
+var (
+	sql_dialect = map[string]uint8{
+		"postgres":  POSTGRESQL,
+		"mysql":     MYSQL,
+		"oracle":    ORACLE,
+		"sqlserver": SQLSERVER,
+		"sqlite":    SQLITE,
+	}
+)
+
+//	constants for SQL write modes
+const (
+	SQL_INSERT       = 1
+	SQL_UPSERT       = 2
+	SQL_UPDATE       = 3
+	SQL_MERGE        = 4
+	SQL_MULTI_INSERT = 5
+)
+
+var (
+	sql_mode = map[string]uint8{
+		"insert":       SQL_INSERT,
+		"upsert":       SQL_UPSERT,
+		"update":       SQL_UPDATE,
+		"merge":        SQL_MERGE,
+		"multi_insert": SQL_MULTI_INSERT,
+	}
+)
+
+//	maximum number of bind parameters in a statement for each dialect
+var sql_max_parameters = map[uint8]int{
+	POSTGRESQL: 65535,
+	MYSQL:      65535,
+	ORACLE:     65535,
+	SQLSERVER:  2100,
+	SQLITE:     999,
+}
+
+//	maximum number of rows in the VALUES clause of a SQL Server INSERT statement
+const sqlServerMaxValuesRows = 1000
+
+//	sqlPlaceholder return the bind parameter placeholder for the n-th value (starting at 1)
+func sqlPlaceholder(dialect uint8, n int) string {
+
+	switch dialect {
+	case POSTGRESQL:
+		return fmt.Sprintf("$%d", n)
+
+	case ORACLE:
+		return fmt.Sprintf(":%d", n)
+
+	case SQLSERVER:
+		return fmt.Sprintf("@p%d", n)
+	}
+
+	return "?"
+}
+
+//	sqlInsertStatement generate an INSERT statement for the given columns and value expressions
+func sqlInsertStatement(tableName string, columns []string, values []string) string {
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		tableName, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
+
+//	sqlMultiInsertStatement generate a single INSERT statement for many rows of value expressions
+func sqlMultiInsertStatement(dialect uint8, tableName string, columns []string, rows [][]string) string {
+
+	var rowList []string
+
+	//	Oracle doesn't support multiple rows in VALUES clause
+	if dialect == ORACLE {
+		for _, values := range rows {
+			rowList = append(rowList, fmt.Sprintf("INTO %s (%s) VALUES (%s)",
+				tableName, strings.Join(columns, ", "), strings.Join(values, ", ")))
+		}
+
+		return "INSERT ALL " + strings.Join(rowList, " ") + " SELECT 1 FROM dual"
+	}
+
+	for _, values := range rows {
+		rowList = append(rowList, "("+strings.Join(values, ", ")+")")
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		tableName, strings.Join(columns, ", "), strings.Join(rowList, ", "))
+}
+
+//	sqlUpdateStatement generate an UPDATE statement where key columns make the WHERE clause;
+//	with positional placeholders, key columns are expected to be the last ones in the list
+func sqlUpdateStatement(tableName string, columns []string, keyColumns []string, values []string) string {
+
+	var setList, whereList []string
+
+	for i, column := range columns {
+		if isKeyColumn(column, keyColumns) {
+			whereList = append(whereList, column+" = "+values[i])
+		} else {
+			setList = append(setList, column+" = "+values[i])
+		}
+	}
+
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s",
+		tableName, strings.Join(setList, ", "), strings.Join(whereList, " AND "))
+}
+
+//	sqlUpsertStatement generate a statement that inserts a row or updates it when the key already exists
+func sqlUpsertStatement(dialect uint8, tableName string, columns []string, keyColumns []string, values []string) string {
+
+	var updateList []string
+
+	switch dialect {
+	case POSTGRESQL, SQLITE:
+		for _, column := range columns {
+			if !isKeyColumn(column, keyColumns) {
+				updateList = append(updateList, column+" = EXCLUDED."+column)
+			}
+		}
+
+		return fmt.Sprintf("%s ON CONFLICT (%s) DO UPDATE SET %s",
+			sqlInsertStatement(tableName, columns, values), strings.Join(keyColumns, ", "), strings.Join(updateList, ", "))
+
+	case MYSQL:
+		for _, column := range columns {
+			if !isKeyColumn(column, keyColumns) {
+				updateList = append(updateList, column+" = VALUES("+column+")")
+			}
+		}
+
+		return fmt.Sprintf("%s ON DUPLICATE KEY UPDATE %s",
+			sqlInsertStatement(tableName, columns, values), strings.Join(updateList, ", "))
+	}
+
+	return sqlMergeStatement(dialect, tableName, columns, keyColumns, values)
+}
+
+//	sqlMergeStatement generate a MERGE statement that inserts a row or updates it when the key already exists
+func sqlMergeStatement(dialect uint8, tableName string, columns []string, keyColumns []string, values []string) string {
+
+	var selectList, onList, updateList, insertList []string
+
+	for i, column := range columns {
+		selectList = append(selectList, values[i]+" AS "+column)
+		insertList = append(insertList, "s."+column)
+
+		if isKeyColumn(column, keyColumns) {
+			onList = append(onList, "t."+column+" = s."+column)
+		} else {
+			updateList = append(updateList, column+" = s."+column)
+		}
+	}
+
+	source := "SELECT " + strings.Join(selectList, ", ")
+	if dialect == ORACLE {
+		source += " FROM dual"
+	}
+
+	statement := fmt.Sprintf("MERGE INTO %s t USING (%s) s ON (%s) WHEN MATCHED THEN UPDATE SET %s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+		tableName, source, strings.Join(onList, " AND "), strings.Join(updateList, ", "),
+		strings.Join(columns, ", "), strings.Join(insertList, ", "))
+
+	//	SQL Server requires MERGE statements to be terminated
+	if dialect == SQLSERVER {
+		statement += ";"
+	}
+
+	return statement
+}
+
+//	isKeyColumn check if a column is part of the key column list
+func isKeyColumn(column string, keyColumns []string) bool {
+
+	for _, keyColumn := range keyColumns {
+		if column == keyColumn {
+			return true
+		}
+	}
+
+	return false
+}
+
+//	sqlValue get a field value to write to a SQL sink, dates in the field's format; nulls are written as the output's
+//	null value, when there's one
+func sqlValue(row *Row, field DataField, nullValue *string) (value string, isNull bool) {
+
+	if date, isTime := row.Get(field.Name).(time.Time); isTime {
+		return date.Format(fieldLayout(data_field_type[field.Type], field.Format)), false
+	}
+
+	if row.Get(field.Name) != nil {
+		return row.GetString(field.Name), false
+	}
+
+	if nullValue != nil {
+		return *nullValue, false
+	}
+
+	return "", true
+}
+
+//	sqlLiteral convert a field value to a SQL literal according to the field type and dialect
+func sqlLiteral(dialect uint8, field DataField, value string) (string, error) {
+
+	fieldType := data_field_type[field.Type]
+
+	if fieldType != STRING {
+		typedValue, err := parseFieldValue(fieldType, field.Format, value)
+		if err != nil || typedValue == nil {
+			return "", errors.New("Invalid " + field.Type + " value for field " + field.Name + ": " + value)
+		}
+
+		switch fieldType {
+		case DATE, TIMESTAMP:
+			return sqlDateLiteral(dialect, fieldType, typedValue.(time.Time)), nil
+
+		case BOOLEAN:
+			return sqlBooleanLiteral(dialect, typedValue.(bool)), nil
+		}
+
+		return exprString(typedValue), nil
+	}
+
+	literal := strings.ReplaceAll(value, "'", "''")
+
+	switch dialect {
+	case MYSQL:
+		//	MySQL uses backslash as escape character in string literals
+		literal = strings.ReplaceAll(value, "\\", "\\\\")
+		literal = strings.ReplaceAll(literal, "'", "''")
+
+	case SQLSERVER:
+		//	non ASCII strings need to be national character literals
+		for _, c := range value {
+			if c > 127 {
+				return "N'" + literal + "'", nil
+			}
+		}
+	}
+
+	return "'" + literal + "'", nil
+}
+
+//	sqlDateLiteral write a date or timestamp literal; the dialects that have them get standard date literals
+func sqlDateLiteral(dialect uint8, fieldType uint8, date time.Time) string {
+
+	literal := "'" + date.Format(fieldLayout(fieldType, "")) + "'"
+
+	switch dialect {
+	case POSTGRESQL, MYSQL, ORACLE:
+		if fieldType == TIMESTAMP {
+			return "TIMESTAMP " + literal
+		}
+		return "DATE " + literal
+	}
+
+	return literal
+}
+
+//	sqlBooleanLiteral write a boolean literal; Oracle and SQL Server store booleans as 1 and 0
+func sqlBooleanLiteral(dialect uint8, value bool) string {
+
+	switch dialect {
+	case ORACLE, SQLSERVER:
+		if value {
+			return "1"
+		}
+		return "0"
+	}
+
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+//	validateSQLOutput validate the dialect, write mode, field list and the key fields required by the write mode
+func validateSQLOutput(dialectName string, modeName string, keyFields []string, fieldList []DataField) error {
+
+	dialect, found := sql_dialect[dialectName]
+	if !found {
+		return errors.New("Invalid SQL dialect: " + dialectName)
+	}
+
+	mode, found := sql_mode[modeName]
+	if !found {
+		return errors.New("Invalid SQL write mode: " + modeName)
+	}
+
+	if mode == SQL_MERGE && (dialect == MYSQL || dialect == SQLITE) {
+		return errors.New("SQL dialect doesn't support MERGE statements: " + dialectName)
+	}
+
+	//	there must be at least one field
+	if len(fieldList) == 0 {
+		return errors.New("Output format need at least one field")
+	}
+
+	for _, field := range fieldList {
+		_, found := data_field_type[field.Type]
+		if !found {
+			return errors.New("Invalid field type: " + field.Type)
+		}
+	}
+
+	//	upsert, merge and update modes need key fields to identify the target rows
+	if mode == SQL_UPSERT || mode == SQL_MERGE || mode == SQL_UPDATE {
+		if len(keyFields) == 0 {
+			return errors.New("SQL write mode requires key fields: " + modeName)
+		}
+
+		for _, keyField := range keyFields {
+			found := false
+			for _, field := range fieldList {
+				if field.Name == keyField {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errors.New("Key field not found in field list: " + keyField)
+			}
+		}
+
+		if len(keyFields) >= len(fieldList) {
+			return errors.New("SQL write mode requires at least one non key field: " + modeName)
+		}
+	}
+
+	return nil
+}
+
+//	sqlColumns get the target columns of a field list, with key columns in a separate list
+func sqlColumns(fieldList []DataField, keyFields []string) (columns []string, keyColumns []string) {
+
+	for _, field := range fieldList {
+		columns = append(columns, columnName(field))
+
+		if isKeyColumn(field.Name, keyFields) {
+			keyColumns = append(keyColumns, columnName(field))
+		}
+	}
+
+	return columns, keyColumns
+}
+
+//	sqlFieldOrder get the field list in statement order: for updates, key fields are the last ones
+//	so positional values match the WHERE clause
+func sqlFieldOrder(mode uint8, fieldList []DataField, keyFields []string) []DataField {
+
+	if mode != SQL_UPDATE {
+		return fieldList
+	}
+
+	var orderedList []DataField
+
+	for _, field := range fieldList {
+		if !isKeyColumn(field.Name, keyFields) {
+			orderedList = append(orderedList, field)
+		}
+	}
+	for _, field := range fieldList {
+		if isKeyColumn(field.Name, keyFields) {
+			orderedList = append(orderedList, field)
+		}
+	}
+
+	return orderedList
+}
+
+//	columnName get the target column name for a field
+func columnName(field DataField) string {
+
+	if len(field.Column) > 0 {
+		return field.Column
+	}
+
+	return field.Name
+}
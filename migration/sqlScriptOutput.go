@@ -0,0 +1,279 @@
+///////////////////////////////////////////////////////////////////////////////
+//	sqlScriptOutput.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a SQL script file as a data output sink
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const defaultRowsPerStatement = 100
+
+//	attributes for a SQL script output sink
+type sqlScriptOutput struct {
+	FileName    string
+	Compression string
+	Dialect     string
+	TableName   string
+	Mode        string
+	KeyFields   []string
+	BatchSize   int
+	NullValue   *string
+	DoneMarker  bool
+	FieldList   []DataField
+
+	NextStep DataPipelineStep
+
+	scriptFile   io.WriteCloser
+	scriptWriter *bufio.Writer
+	columnList   []DataField
+	pendingRows  [][]string
+	resumable    bool
+}
+
+//	NewSQLScriptOutput create a new sqlScriptOutput
+func NewSQLScriptOutput(config JobOutput) DataOutputSink {
+
+	output := &sqlScriptOutput{
+		FileName:    config.FileName,
+		Compression: config.Compression,
+		Dialect:     config.Dialect,
+		TableName:   config.TableName,
+		Mode:        config.Mode,
+		KeyFields:   config.KeyFields,
+		BatchSize:   config.BatchSize,
+		NullValue:   config.NullValue,
+		DoneMarker:  config.DoneMarker,
+		FieldList:   config.FieldList,
+	}
+
+	//	default values
+	if len(output.Mode) == 0 {
+		output.Mode = "insert"
+	}
+	if output.BatchSize == 0 {
+		output.BatchSize = defaultRowsPerStatement
+	}
+
+	return output
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *sqlScriptOutput) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *sqlScriptOutput) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ValidateFormat validate the script output configuration
+func (s *sqlScriptOutput) ValidateFormat() error {
+
+	if len(s.FileName) == 0 {
+		return errors.New("Missing SQL script file name")
+	}
+
+	if len(s.TableName) == 0 {
+		return errors.New("Missing SQL table name")
+	}
+
+	err := validateSQLOutput(s.Dialect, s.Mode, s.KeyFields, s.FieldList)
+	if err != nil {
+		return err
+	}
+
+	if s.BatchSize < 0 {
+		return errors.New("Invalid SQL batch size: " + strconv.Itoa(s.BatchSize))
+	}
+
+	//	SQL Server limits the number of rows in a VALUES clause
+	if sql_mode[s.Mode] == SQL_MULTI_INSERT && sql_dialect[s.Dialect] == SQLSERVER && s.BatchSize > sqlServerMaxValuesRows {
+		return errors.New("Invalid SQL batch size for sqlserver multi_insert (max " + strconv.Itoa(sqlServerMaxValuesRows) +
+			"): " + strconv.Itoa(s.BatchSize))
+	}
+
+	return validateOutputCompression(s.FileName, s.Compression)
+}
+
+//	Start create the SQL script file; when the job resumes from a checkpoint, the statements written up to it are kept
+func (s *sqlScriptOutput) Start(job *JobContext) error {
+
+	var err error
+
+	s.resumable = job != nil && job.Resumable
+
+	if job != nil && job.Resume != nil {
+		s.scriptFile, err = resumeOutputFile(s.FileName, s.Compression, job.Resume.OutputSize)
+		if err != nil {
+			return errors.New("fail resuming SQL script file: " + err.Error())
+		}
+	} else {
+		s.scriptFile, err = createOutputFile(s.FileName, s.Compression)
+		if err != nil {
+			return errors.New("fail creating SQL script file: " + err.Error())
+		}
+	}
+
+	s.scriptWriter = bufio.NewWriter(s.scriptFile)
+	s.columnList = sqlFieldOrder(sql_mode[s.Mode], s.FieldList, s.KeyFields)
+	s.pendingRows = nil
+
+	return nil
+}
+
+//	ProcessRow write a SQL statement for the data row into the script
+func (s *sqlScriptOutput) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	dialect := sql_dialect[s.Dialect]
+
+	var values []string
+
+	for _, field := range s.columnList {
+		fieldValue, isNull := sqlValue(row, field, s.NullValue)
+		if isNull {
+			values = append(values, "NULL")
+			continue
+		}
+
+		literal, err := sqlLiteral(dialect, field, fieldValue)
+		if err != nil {
+			return false, err
+		}
+		values = append(values, literal)
+	}
+
+	//	for multiple rows inserts, rows are written when the batch is complete
+	if sql_mode[s.Mode] == SQL_MULTI_INSERT {
+		s.pendingRows = append(s.pendingRows, values)
+
+		if len(s.pendingRows) >= s.BatchSize {
+			err = s.writePendingRows()
+		}
+	} else {
+		err = s.writeStatement(s.buildStatement(values))
+	}
+	if err != nil {
+		return false, NewFatalError(errors.New("fail writing SQL script file: " + err.Error()))
+	}
+
+	//	if available, invoke the next step in the pipeline
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	ValidateCheckpoint check the SQL script file can be resumed from a checkpoint
+func (s *sqlScriptOutput) ValidateCheckpoint() error {
+	return validateResumableOutput(s.FileName, s.Compression)
+}
+
+//	Checkpoint write the pending rows and get the SQL script file size
+func (s *sqlScriptOutput) Checkpoint() (outputSize int64, err error) {
+
+	err = s.writePendingRows()
+	if err == nil {
+		err = s.scriptWriter.Flush()
+	}
+	if err == nil {
+		outputSize, err = outputFileSize(s.scriptFile)
+	}
+	if err != nil {
+		return 0, errors.New("fail writing SQL script file: " + err.Error())
+	}
+
+	return outputSize, nil
+}
+
+//	Finish write the pending rows and close the SQL script file, renaming it into place when the job succeeds; the
+//	script of a failed job is removed, unless the job can resume from a checkpoint
+func (s *sqlScriptOutput) Finish(jobErr error) error {
+
+	if s.scriptFile == nil {
+		return nil
+	}
+
+	err := s.writePendingRows()
+	if err == nil {
+		err = s.scriptWriter.Flush()
+	}
+
+	closeErr := s.scriptFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	s.scriptFile = nil
+
+	if err != nil || jobErr != nil {
+		if !s.resumable {
+			discardOutputFile(s.FileName)
+		}
+		if err != nil {
+			return errors.New("fail writing SQL script file: " + err.Error())
+		}
+		return nil
+	}
+
+	err = commitOutputFile(s.FileName, s.DoneMarker)
+	if err != nil {
+		return errors.New("fail writing SQL script file: " + err.Error())
+	}
+
+	return nil
+}
+
+//	buildStatement generate the SQL statement for a row according to the dialect and write mode
+func (s *sqlScriptOutput) buildStatement(values []string) string {
+
+	dialect := sql_dialect[s.Dialect]
+	columns, keyColumns := sqlColumns(s.columnList, s.KeyFields)
+
+	switch sql_mode[s.Mode] {
+	case SQL_UPSERT:
+		return sqlUpsertStatement(dialect, s.TableName, columns, keyColumns, values)
+
+	case SQL_MERGE:
+		return sqlMergeStatement(dialect, s.TableName, columns, keyColumns, values)
+
+	case SQL_UPDATE:
+		return sqlUpdateStatement(s.TableName, columns, keyColumns, values)
+	}
+
+	return sqlInsertStatement(s.TableName, columns, values)
+}
+
+//	writePendingRows write a multiple rows INSERT statement for the pending rows
+func (s *sqlScriptOutput) writePendingRows() error {
+
+	if len(s.pendingRows) == 0 {
+		return nil
+	}
+
+	columns, _ := sqlColumns(s.columnList, s.KeyFields)
+	statement := sqlMultiInsertStatement(sql_dialect[s.Dialect], s.TableName, columns, s.pendingRows)
+	s.pendingRows = nil
+
+	return s.writeStatement(statement)
+}
+
+//	writeStatement write a terminated statement into the script
+func (s *sqlScriptOutput) writeStatement(statement string) error {
+
+	if !strings.HasSuffix(statement, ";") {
+		statement += ";"
+	}
+
+	_, err := s.scriptWriter.WriteString(statement + "\n")
+
+	return err
+}
@@ -0,0 +1,351 @@
+///////////////////////////////////////////////////////////////////////////////
+//	sqlScriptOutput_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for a SQL script file as a data output sink
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+//	Test_SQLLiteral test cases for SQL literals generation
+func Test_SQLLiteral(t *testing.T) {
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		dialect  string
+		field    DataField
+		value    string
+		output   string
+	}{
+		{scenario: "integer", dialect: "postgres", field: DataField{Name: "test", Type: "integer"}, value: " 001", output: "1"},
+		{scenario: "invalid integer", dialect: "postgres", field: DataField{Name: "test", Type: "integer"}, value: "x1",
+			output: "Invalid integer value for field test: x1"},
+		{scenario: "decimal", dialect: "postgres", field: DataField{Name: "test", Type: "decimal"}, value: " 10.50", output: "10.50"},
+		{scenario: "invalid decimal", dialect: "postgres", field: DataField{Name: "test", Type: "decimal"}, value: "1,5",
+			output: "Invalid decimal value for field test: 1,5"},
+		{scenario: "date", dialect: "postgres", field: DataField{Name: "test", Type: "date", Format: "DD/MM/YYYY"}, value: "19/10/2026",
+			output: "DATE '2026-10-19'"},
+		{scenario: "sqlite timestamp", dialect: "sqlite", field: DataField{Name: "test", Type: "timestamp"}, value: "2026-10-19 08:30:00",
+			output: "'2026-10-19 08:30:00'"},
+		{scenario: "boolean", dialect: "mysql", field: DataField{Name: "test", Type: "boolean"}, value: "true", output: "TRUE"},
+		{scenario: "sqlserver boolean", dialect: "sqlserver", field: DataField{Name: "test", Type: "boolean"}, value: "false", output: "0"},
+		{scenario: "string", dialect: "postgres", field: DataField{Name: "test", Type: "string"}, value: "O'Neil \\", output: "'O''Neil \\'"},
+		{scenario: "mysql string", dialect: "mysql", field: DataField{Name: "test", Type: "string"}, value: "O'Neil \\", output: "'O''Neil \\\\'"},
+		{scenario: "sqlserver ascii string", dialect: "sqlserver", field: DataField{Name: "test", Type: "string"}, value: "Sao Paulo", output: "'Sao Paulo'"},
+		{scenario: "sqlserver national string", dialect: "sqlserver", field: DataField{Name: "test", Type: "string"}, value: "São Paulo", output: "N'São Paulo'"},
+	}
+
+	t.Run(">>> validation of SQL literals", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			got, err := sqlLiteral(sql_dialect[test.dialect], test.field, test.value)
+			if err != nil {
+				got = err.Error()
+			}
+			want := test.output
+
+			if want != got {
+				t.Errorf("fail in sqlLiteral(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_SQLScriptOutput_ValidateFormat test cases for validation of script output configuration
+func Test_SQLScriptOutput_ValidateFormat(t *testing.T) {
+
+	fieldList := []DataField{{Name: "id", Type: "integer"}}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		input    JobOutput
+		output   string
+	}{
+		{scenario: "sqlserver batch too large", input: JobOutput{Dialect: "sqlserver", Mode: "multi_insert", BatchSize: 1001},
+			output: "Invalid SQL batch size for sqlserver multi_insert (max 1000): 1001"},
+		{scenario: "sqlserver batch", input: JobOutput{Dialect: "sqlserver", Mode: "multi_insert", BatchSize: 1000}},
+		{scenario: "mysql batch", input: JobOutput{Dialect: "mysql", Mode: "multi_insert", BatchSize: 5000}},
+		{scenario: "unsupported compression", input: JobOutput{Dialect: "mysql", Compression: "bzip2"},
+			output: "Unsupported compression for output files, only gzip is available: testScript.sql"},
+	}
+
+	t.Run(">>> validation of SQL script output configuration", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			test.input.FileName = "testScript.sql"
+			test.input.TableName = "test"
+			test.input.FieldList = fieldList
+
+			got := ""
+			want := test.output
+
+			err := NewSQLScriptOutput(test.input).ValidateFormat()
+			if err != nil {
+				got = err.Error()
+			}
+
+			if want != got {
+				t.Errorf("fail in ValidateFormat(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_SQLScriptOutput_ImportData test cases for writing imported data into a SQL script
+func Test_SQLScriptOutput_ImportData(t *testing.T) {
+
+	fieldList := []DataField{
+		{Name: "test_1", Type: "integer", Column: "id"},
+		{Name: "test_2", Type: "string", Column: "name"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		input    JobOutput
+		output   string
+	}{
+		{scenario: "insert", input: JobOutput{Dialect: "postgres"},
+			output: "INSERT INTO test (id, name) VALUES (1, 'LINE#1');\nINSERT INTO test (id, name) VALUES (2, 'LINE''2');\n" +
+				"INSERT INTO test (id, name) VALUES (3, 'LINE#3');\n"},
+		{scenario: "multi insert", input: JobOutput{Dialect: "mysql", Mode: "multi_insert", BatchSize: 2},
+			output: "INSERT INTO test (id, name) VALUES (1, 'LINE#1'), (2, 'LINE''2');\nINSERT INTO test (id, name) VALUES (3, 'LINE#3');\n"},
+		{scenario: "oracle multi insert", input: JobOutput{Dialect: "oracle", Mode: "multi_insert", BatchSize: 3},
+			output: "INSERT ALL INTO test (id, name) VALUES (1, 'LINE#1') INTO test (id, name) VALUES (2, 'LINE''2') " +
+				"INTO test (id, name) VALUES (3, 'LINE#3') SELECT 1 FROM dual;\n"},
+		{scenario: "update", input: JobOutput{Dialect: "sqlite", Mode: "update", KeyFields: []string{"test_1"}},
+			output: "UPDATE test SET name = 'LINE#1' WHERE id = 1;\nUPDATE test SET name = 'LINE''2' WHERE id = 2;\n" +
+				"UPDATE test SET name = 'LINE#3' WHERE id = 3;\n"},
+	}
+
+	t.Run(">>> validation of SQL script generation", func(t *testing.T) {
+
+		const testFileName = "testData.txt"
+		const testScriptName = "testScript.sql"
+
+		err := os.WriteFile(testFileName, []byte("1,LINE#1\n2,LINE'2\n3,LINE#3\n"), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(testFileName)
+		defer os.Remove(testScriptName)
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			test.input.FileName = testScriptName
+			test.input.TableName = "test"
+			test.input.FieldList = fieldList
+
+			testDataSource := NewCSVInputFile(JobInput{FileName: testFileName, FieldSeparator: ",", FieldList: fieldList})
+			testOutput := NewSQLScriptOutput(test.input)
+
+			err = testOutput.ValidateFormat()
+			if err != nil {
+				t.Errorf("unexpected error in ValidateFormat(): %s", err)
+			}
+
+			err = testOutput.Start(nil)
+			if err != nil {
+				t.Errorf("unexpected error in Start(): %s", err)
+			}
+
+			_, err = testDataSource.ImportData(context.Background(), testOutput)
+			if err != nil {
+				t.Errorf("unexpected error in ImportData(): %s", err)
+			}
+
+			err = testOutput.Finish(nil)
+			if err != nil {
+				t.Errorf("unexpected error in Finish(): %s", err)
+			}
+
+			script, err := os.ReadFile(testScriptName)
+			if err != nil {
+				t.Errorf("unexpected error reading SQL script: %s", err)
+			}
+
+			got := string(script)
+			want := test.output
+
+			if want != got {
+				t.Errorf("fail generating SQL script: expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_SQLScriptOutput_Nulls test cases for writing nulls into a SQL script
+func Test_SQLScriptOutput_Nulls(t *testing.T) {
+
+	fieldList := []DataField{
+		{Name: "test_1", Type: "integer", Column: "id"},
+		{Name: "test_2", Type: "string", Column: "name", NullValues: []string{"\\N"}},
+	}
+
+	emptyString := ""
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario  string
+		nullValue *string
+		output    string
+	}{
+		{scenario: "nulls as NULL",
+			output: "INSERT INTO test (id, name) VALUES (1, NULL), (2, NULL), (3, ''), (NULL, 'LINE#4');\n"},
+		{scenario: "nulls as empty strings", nullValue: &emptyString,
+			output: "INSERT INTO test (id, name) VALUES (1, ''), (2, ''), (3, '');\nInvalid integer value for field test_1: "},
+	}
+
+	t.Run(">>> validation of nulls in SQL script", func(t *testing.T) {
+
+		const testFileName = "testData.txt"
+		const testScriptName = "testScript.sql"
+
+		err := os.WriteFile(testFileName, []byte("1,\\N\n2\n3,\n ,LINE#4\n"), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(testFileName)
+		defer os.Remove(testScriptName)
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			rejects := &rowRejecterMock{}
+
+			testDataSource := NewCSVInputFile(JobInput{FileName: testFileName, FieldSeparator: ",", FieldList: fieldList})
+			testDataSource.SetRowRejecter(rejects)
+
+			testOutput := NewSQLScriptOutput(JobOutput{FileName: testScriptName, Dialect: "postgres", Mode: "multi_insert",
+				TableName: "test", NullValue: test.nullValue, FieldList: fieldList})
+
+			err = testOutput.Start(nil)
+			if err != nil {
+				t.Errorf("unexpected error in Start(): %s", err)
+			}
+
+			_, err = testDataSource.ImportData(context.Background(), testOutput)
+			if err != nil {
+				t.Errorf("unexpected error in ImportData(): %s", err)
+			}
+
+			err = testOutput.Finish(nil)
+			if err != nil {
+				t.Errorf("unexpected error in Finish(): %s", err)
+			}
+
+			script, err := os.ReadFile(testScriptName)
+			if err != nil {
+				t.Errorf("unexpected error reading SQL script: %s", err)
+			}
+
+			got := string(script) + strings.Join(rejects.errList, ", ")
+			want := test.output
+
+			if want != got {
+				t.Errorf("fail writing nulls in SQL script: expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_SQLScriptOutput_Commit test cases for renaming the SQL script into place when the job finishes
+func Test_SQLScriptOutput_Commit(t *testing.T) {
+
+	const testScriptName = "testScript.sql"
+
+	defer os.Remove(testScriptName)
+	defer os.Remove(testScriptName + ".done")
+
+	fileExists := func(fileName string) bool {
+		_, err := os.Stat(fileName)
+		return err == nil
+	}
+
+	newOutput := func() DataOutputSink {
+		return NewSQLScriptOutput(JobOutput{
+			FileName:   testScriptName,
+			Dialect:    "postgres",
+			TableName:  "test",
+			DoneMarker: true,
+			FieldList:  []DataField{{Name: "id", Type: "integer"}},
+		})
+	}
+
+	t.Run(">>> validation of script of a successful job", func(t *testing.T) {
+
+		testOutput := newOutput()
+
+		err := testOutput.Start(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Start(): %s", err)
+		}
+
+		row := NewRow()
+		row.Set("id", int64(1))
+
+		_, err = testOutput.ProcessRow(row)
+		if err != nil {
+			t.Errorf("unexpected error in ProcessRow(): %s", err)
+		}
+
+		//	the script is only in place after the job finishes
+		if fileExists(testScriptName) || !fileExists(partialFileName(testScriptName)) {
+			t.Errorf("fail in Start(): SQL script must be written with its partial name")
+		}
+
+		err = testOutput.Finish(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		if !fileExists(testScriptName) || !fileExists(testScriptName+".done") || fileExists(partialFileName(testScriptName)) {
+			t.Errorf("fail in Finish(): SQL script and done marker must be in place")
+		}
+	})
+
+	t.Run(">>> validation of script of a failed job", func(t *testing.T) {
+
+		previousScript, err := os.ReadFile(testScriptName)
+		if err != nil {
+			t.Errorf("unexpected error reading SQL script: %s", err)
+		}
+
+		testOutput := newOutput()
+
+		err = testOutput.Start(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Start(): %s", err)
+		}
+
+		err = testOutput.Finish(errors.New("job failed"))
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		//	the partial script is removed, and the script of the previous run is kept
+		script, _ := os.ReadFile(testScriptName)
+		if fileExists(partialFileName(testScriptName)) || string(previousScript) != string(script) {
+			t.Errorf("fail in Finish(): unexpected SQL script of a failed job: %s", string(script))
+		}
+	})
+}
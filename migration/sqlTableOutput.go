@@ -0,0 +1,421 @@
+///////////////////////////////////////////////////////////////////////////////
+//	sqlTableOutput.go  -  Oct-19-2026  -  aldebap
+//
+//	Implementation for a database table as a data output sink
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+//	constants for SQL transaction modes
+const (
+	TRANSACTION_PER_BATCH = 1
+	TRANSACTION_PER_JOB   = 2
+)
+
+var (
+	sql_transaction = map[string]uint8{
+		"batch": TRANSACTION_PER_BATCH,
+		"job":   TRANSACTION_PER_JOB,
+	}
+)
+
+const defaultBatchSize = 1000
+
+//	attributes for a SQL table output sink: rows are committed every batch_size rows, or once for the whole job;
+//	in multi_insert mode each batch is also written with multiple rows INSERT statements; a job with checkpoints
+//	only commits at the checkpoints, so a resumed job doesn't write again the rows committed after the last one
+//	the database driver must be registered in database/sql by the application
+type sqlTableOutput struct {
+	Driver      string
+	DataSource  string
+	Dialect     string
+	TableName   string
+	Mode        string
+	KeyFields   []string
+	BatchSize   int
+	Transaction string
+	NullValue   *string
+	FieldList   []DataField
+
+	NextStep DataPipelineStep
+
+	ctx         context.Context
+	db          *sql.DB
+	tx          *sql.Tx
+	statement   *sql.Stmt
+	txStatement *sql.Stmt
+	columnList  []DataField
+	pendingRows [][]interface{}
+	batchSize   int
+	batchRows   int
+	perBatchTrx bool
+	resumable   bool
+}
+
+//	NewSQLTableOutput create a new sqlTableOutput
+func NewSQLTableOutput(config JobOutput) DataOutputSink {
+
+	output := &sqlTableOutput{
+		Driver:      config.Driver,
+		DataSource:  config.DataSource,
+		Dialect:     config.Dialect,
+		TableName:   config.TableName,
+		Mode:        config.Mode,
+		KeyFields:   config.KeyFields,
+		BatchSize:   config.BatchSize,
+		Transaction: config.Transaction,
+		NullValue:   config.NullValue,
+		FieldList:   config.FieldList,
+	}
+
+	//	default values
+	if len(output.Mode) == 0 {
+		output.Mode = "insert"
+	}
+	if len(output.Transaction) == 0 {
+		output.Transaction = "batch"
+	}
+
+	return output
+}
+
+//	SetNextStep set the next step in data pipeline
+func (s *sqlTableOutput) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+//	GetNextStep get the next step in data pipeline
+func (s *sqlTableOutput) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+//	ValidateFormat validate the table output configuration
+func (s *sqlTableOutput) ValidateFormat() error {
+
+	if len(s.Driver) == 0 {
+		return errors.New("Missing SQL driver name")
+	}
+
+	if len(s.TableName) == 0 {
+		return errors.New("Missing SQL table name")
+	}
+
+	err := validateSQLOutput(s.Dialect, s.Mode, s.KeyFields, s.FieldList)
+	if err != nil {
+		return err
+	}
+
+	_, found := sql_transaction[s.Transaction]
+	if !found {
+		return errors.New("Invalid SQL transaction mode: " + s.Transaction)
+	}
+
+	if s.BatchSize < 0 {
+		return errors.New("Invalid SQL batch size: " + strconv.Itoa(s.BatchSize))
+	}
+
+	//	with a single transaction, the batch size only sets the rows per multiple rows INSERT statement
+	if s.BatchSize > 0 && sql_transaction[s.Transaction] == TRANSACTION_PER_JOB && sql_mode[s.Mode] != SQL_MULTI_INSERT {
+		return errors.New("SQL batch size needs batch transactions or multi_insert mode: " + s.Mode)
+	}
+
+	return nil
+}
+
+//	Start connect to the database and prepare the write statement; statements still running when the job is
+//	interrupted are cancelled
+func (s *sqlTableOutput) Start(job *JobContext) error {
+
+	var err error
+
+	s.ctx = contextOf(job)
+
+	s.batchSize = s.BatchSize
+	if s.batchSize == 0 {
+		s.batchSize = defaultBatchSize
+	}
+	s.pendingRows = nil
+
+	s.db, err = sql.Open(s.Driver, s.DataSource)
+	if err != nil {
+		return errors.New("fail opening database: " + err.Error())
+	}
+
+	err = s.db.PingContext(s.ctx)
+	if err != nil {
+		s.db.Close()
+		return errors.New("fail connecting to database: " + err.Error())
+	}
+
+	//	multiple rows statements are built for each batch, as the number of rows changes
+	statement := s.buildStatement()
+
+	if sql_mode[s.Mode] != SQL_MULTI_INSERT {
+		s.statement, err = s.db.Prepare(statement)
+		if err != nil {
+			s.db.Close()
+			return errors.New("fail preparing SQL statement: " + err.Error())
+		}
+	}
+
+	s.perBatchTrx = sql_transaction[s.Transaction] == TRANSACTION_PER_BATCH
+	s.resumable = job != nil && job.Resumable
+
+	return nil
+}
+
+//	buildStatement generate the SQL statement according to the dialect and write mode
+func (s *sqlTableOutput) buildStatement() string {
+
+	dialect := sql_dialect[s.Dialect]
+	mode := sql_mode[s.Mode]
+
+	s.columnList = sqlFieldOrder(mode, s.FieldList, s.KeyFields)
+	columns, keyColumns := sqlColumns(s.columnList, s.KeyFields)
+
+	var values []string
+
+	for i := range columns {
+		values = append(values, sqlPlaceholder(dialect, i+1))
+	}
+
+	switch mode {
+	case SQL_UPSERT:
+		return sqlUpsertStatement(dialect, s.TableName, columns, keyColumns, values)
+
+	case SQL_MERGE:
+		return sqlMergeStatement(dialect, s.TableName, columns, keyColumns, values)
+
+	case SQL_UPDATE:
+		return sqlUpdateStatement(s.TableName, columns, keyColumns, values)
+	}
+
+	return sqlInsertStatement(s.TableName, columns, values)
+}
+
+//	ProcessRow write the data row into the target table
+func (s *sqlTableOutput) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	//	start a new transaction if there's none
+	if s.tx == nil {
+		err = s.begin()
+		if err != nil {
+			return false, NewFatalError(err)
+		}
+	}
+
+	var args []interface{}
+
+	for _, field := range s.columnList {
+		fieldValue, isNull := sqlValue(row, field, s.NullValue)
+		if isNull {
+			args = append(args, nil)
+			continue
+		}
+
+		value, err := sqlArgument(field, fieldValue)
+		if err != nil {
+			return false, err
+		}
+		args = append(args, value)
+	}
+
+	//	for multiple rows inserts, rows are written when the batch is complete
+	if sql_mode[s.Mode] == SQL_MULTI_INSERT {
+		s.pendingRows = append(s.pendingRows, args)
+
+		if len(s.pendingRows) >= s.batchSize {
+			err = s.writePendingRows()
+		}
+	} else {
+		_, err = s.txStatement.ExecContext(s.ctx, args...)
+		if err != nil {
+			err = errors.New("fail writing row into table: " + err.Error())
+		}
+	}
+	if err != nil {
+		return false, NewFatalError(err)
+	}
+	s.batchRows++
+
+	//	commit when the batch is complete, unless the commits are made by the checkpoints
+	if s.perBatchTrx && !s.resumable && s.batchRows >= s.batchSize {
+		err = s.commit()
+		if err != nil {
+			return false, NewFatalError(err)
+		}
+	}
+
+	//	if available, invoke the next step in the pipeline
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	begin start a transaction, with the write statement bound to it
+func (s *sqlTableOutput) begin() error {
+
+	var err error
+
+	s.tx, err = s.db.BeginTx(s.ctx, nil)
+	if err != nil {
+		s.tx = nil
+		return errors.New("fail starting transaction: " + err.Error())
+	}
+	s.batchRows = 0
+
+	//	the statement is bound once for the transaction, as the bound statements are only released on commit
+	if s.statement != nil {
+		s.txStatement = s.tx.StmtContext(s.ctx, s.statement)
+	}
+
+	return nil
+}
+
+//	writePendingRows write the rows of a multiple rows insert batch, with as many rows per statement as the
+//	dialect's bind parameters allow
+func (s *sqlTableOutput) writePendingRows() error {
+
+	if len(s.pendingRows) == 0 {
+		return nil
+	}
+
+	dialect := sql_dialect[s.Dialect]
+	columns, _ := sqlColumns(s.columnList, s.KeyFields)
+
+	rowsPerStatement := sql_max_parameters[dialect] / len(columns)
+	if dialect == SQLSERVER && rowsPerStatement > sqlServerMaxValuesRows {
+		rowsPerStatement = sqlServerMaxValuesRows
+	}
+	if rowsPerStatement < 1 {
+		rowsPerStatement = 1
+	}
+
+	pendingRows := s.pendingRows
+	s.pendingRows = nil
+
+	for len(pendingRows) > 0 {
+		statementRows := pendingRows
+		if len(statementRows) > rowsPerStatement {
+			statementRows = statementRows[:rowsPerStatement]
+		}
+		pendingRows = pendingRows[len(statementRows):]
+
+		var values [][]string
+		var args []interface{}
+
+		for _, rowArgs := range statementRows {
+			var placeholders []string
+			for range rowArgs {
+				args = append(args, nil)
+				placeholders = append(placeholders, sqlPlaceholder(dialect, len(args)))
+			}
+			copy(args[len(args)-len(rowArgs):], rowArgs)
+			values = append(values, placeholders)
+		}
+
+		_, err := s.tx.ExecContext(s.ctx, sqlMultiInsertStatement(dialect, s.TableName, columns, values), args...)
+		if err != nil {
+			return errors.New("fail writing rows into table: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+//	commit write the pending rows and commit the transaction
+func (s *sqlTableOutput) commit() error {
+
+	err := s.writePendingRows()
+	if err != nil {
+		return err
+	}
+
+	err = s.tx.Commit()
+	s.tx = nil
+	s.txStatement = nil
+	if err != nil {
+		return errors.New("fail committing transaction: " + err.Error())
+	}
+
+	return nil
+}
+
+//	ValidateCheckpoint check the rows are committed in batches, so the rows up to a checkpoint can be committed;
+//	the batches then last from one checkpoint to the next
+func (s *sqlTableOutput) ValidateCheckpoint() error {
+
+	if sql_transaction[s.Transaction] != TRANSACTION_PER_BATCH {
+		return errors.New("Checkpoints need batch transactions: " + s.Transaction)
+	}
+
+	return nil
+}
+
+//	Checkpoint commit the rows written so far; the table has no size to keep
+func (s *sqlTableOutput) Checkpoint() (outputSize int64, err error) {
+
+	if s.tx == nil {
+		return 0, nil
+	}
+
+	return 0, s.commit()
+}
+
+//	Finish commit pending rows (or roll them back when the job failed) and disconnect from the database
+func (s *sqlTableOutput) Finish(jobErr error) error {
+
+	var err error
+
+	if s.tx != nil {
+		if jobErr == nil {
+			err = s.commit()
+		}
+		if s.tx != nil {
+			s.tx.Rollback()
+		}
+		s.tx = nil
+		s.txStatement = nil
+	}
+	s.pendingRows = nil
+
+	if s.statement != nil {
+		s.statement.Close()
+	}
+	if s.db != nil {
+		s.db.Close()
+	}
+
+	return err
+}
+
+//	sqlArgument convert a field value to the bind parameter type
+func sqlArgument(field DataField, value string) (interface{}, error) {
+
+	fieldType := data_field_type[field.Type]
+
+	if fieldType == STRING {
+		return value, nil
+	}
+
+	typedValue, err := parseFieldValue(fieldType, field.Format, value)
+	if err != nil || typedValue == nil {
+		return nil, errors.New("Invalid " + field.Type + " value for field " + field.Name + ": " + value)
+	}
+
+	//	decimals are bound as text, so the drivers don't round them
+	if decimal, isDecimal := typedValue.(Decimal); isDecimal {
+		return decimal.String(), nil
+	}
+
+	return typedValue, nil
+}
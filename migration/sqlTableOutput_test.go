@@ -0,0 +1,427 @@
+///////////////////////////////////////////////////////////////////////////////
+//	sqlTableOutput_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for a database table as a data output sink
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+//	fake database driver that records the statements executed, and the rows committed; writing a row with
+//	the fail value fails
+type fakeDriver struct{}
+
+type fakeDatabase struct {
+	statements []string
+	rows       [][]driver.Value
+	pending    [][]driver.Value
+	committed  [][]driver.Value
+	commits    int
+	rollbacks  int
+	failValue  driver.Value
+}
+
+var fakeDatabases = map[string]*fakeDatabase{}
+
+type fakeConn struct {
+	database *fakeDatabase
+}
+
+type fakeStmt struct {
+	database *fakeDatabase
+	query    string
+}
+
+type fakeTx struct {
+	database *fakeDatabase
+}
+
+func init() {
+	sql.Register("fake", &fakeDriver{})
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+
+	database, found := fakeDatabases[name]
+	if !found {
+		return nil, errors.New("unknown database: " + name)
+	}
+
+	return &fakeConn{database: database}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+
+	c.database.statements = append(c.database.statements, query)
+
+	return &fakeStmt{database: c.database, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return &fakeTx{database: c.database}, nil
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+
+	for _, arg := range args {
+		if s.database.failValue != nil && arg == s.database.failValue {
+			return nil, errors.New("fake write error")
+		}
+	}
+
+	s.database.rows = append(s.database.rows, args)
+	s.database.pending = append(s.database.pending, args)
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, io.EOF
+}
+
+func (t *fakeTx) Commit() error {
+	t.database.commits++
+	t.database.committed = append(t.database.committed, t.database.pending...)
+	t.database.pending = nil
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.database.rollbacks++
+	t.database.pending = nil
+	return nil
+}
+
+//	Test_SQLTableOutput_ValidateFormat test cases for validation of table output configuration
+func Test_SQLTableOutput_ValidateFormat(t *testing.T) {
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer"},
+		{Name: "name", Type: "string"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		input    JobOutput
+		output   string
+	}{
+		{scenario: "missing driver", input: JobOutput{}, output: "Missing SQL driver name"},
+		{scenario: "missing table", input: JobOutput{Driver: "fake"}, output: "Missing SQL table name"},
+		{scenario: "invalid dialect", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "xpto"}, output: "Invalid SQL dialect: xpto"},
+		{scenario: "invalid mode", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres", Mode: "xpto"}, output: "Invalid SQL write mode: xpto"},
+		{scenario: "merge not supported", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "mysql", Mode: "merge"},
+			output: "SQL dialect doesn't support MERGE statements: mysql"},
+		{scenario: "empty field list", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres"}, output: "Output format need at least one field"},
+		{scenario: "batch size with job transaction", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres", Transaction: "job",
+			BatchSize: 100, FieldList: fieldList}, output: "SQL batch size needs batch transactions or multi_insert mode: insert"},
+		{scenario: "invalid transaction", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres", Transaction: "xpto",
+			FieldList: fieldList}, output: "Invalid SQL transaction mode: xpto"},
+		{scenario: "missing key fields", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres", Mode: "upsert",
+			FieldList: fieldList}, output: "SQL write mode requires key fields: upsert"},
+		{scenario: "unknown key field", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres", Mode: "update",
+			KeyFields: []string{"xpto"}, FieldList: fieldList}, output: "Key field not found in field list: xpto"},
+		{scenario: "only key fields", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres", Mode: "update",
+			KeyFields: []string{"id", "name"}, FieldList: fieldList}, output: "SQL write mode requires at least one non key field: update"},
+		{scenario: "valid configuration", input: JobOutput{Driver: "fake", TableName: "test", Dialect: "postgres", Mode: "upsert",
+			KeyFields: []string{"id"}, FieldList: fieldList}, output: ""},
+	}
+
+	t.Run(">>> validation of SQL table output configuration", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			testOutput := NewSQLTableOutput(test.input)
+
+			//	validate the format
+			got := ""
+			want := test.output
+
+			err := testOutput.ValidateFormat()
+			if err != nil {
+				got = err.Error()
+			}
+
+			if want != got {
+				t.Errorf("fail in ValidateFormat(): expected: %s result: %v", want, got)
+			}
+		}
+	})
+}
+
+//	Test_SQLTableOutput_Statement test cases for SQL statement generation
+func Test_SQLTableOutput_Statement(t *testing.T) {
+
+	fieldList := []DataField{
+		{Name: "id", Type: "integer", Column: "customer_id"},
+		{Name: "name", Type: "string"},
+	}
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario string
+		input    JobOutput
+		output   string
+	}{
+		{scenario: "postgres insert", input: JobOutput{Dialect: "postgres", TableName: "customer"},
+			output: "INSERT INTO customer (customer_id, name) VALUES ($1, $2)"},
+		{scenario: "mysql insert", input: JobOutput{Dialect: "mysql", TableName: "customer"},
+			output: "INSERT INTO customer (customer_id, name) VALUES (?, ?)"},
+		{scenario: "postgres upsert", input: JobOutput{Dialect: "postgres", TableName: "customer", Mode: "upsert", KeyFields: []string{"id"}},
+			output: "INSERT INTO customer (customer_id, name) VALUES ($1, $2) ON CONFLICT (customer_id) DO UPDATE SET name = EXCLUDED.name"},
+		{scenario: "mysql upsert", input: JobOutput{Dialect: "mysql", TableName: "customer", Mode: "upsert", KeyFields: []string{"id"}},
+			output: "INSERT INTO customer (customer_id, name) VALUES (?, ?) ON DUPLICATE KEY UPDATE name = VALUES(name)"},
+		{scenario: "oracle upsert", input: JobOutput{Dialect: "oracle", TableName: "customer", Mode: "upsert", KeyFields: []string{"id"}},
+			output: "MERGE INTO customer t USING (SELECT :1 AS customer_id, :2 AS name FROM dual) s ON (t.customer_id = s.customer_id) " +
+				"WHEN MATCHED THEN UPDATE SET name = s.name WHEN NOT MATCHED THEN INSERT (customer_id, name) VALUES (s.customer_id, s.name)"},
+		{scenario: "sqlserver upsert", input: JobOutput{Dialect: "sqlserver", TableName: "customer", Mode: "upsert", KeyFields: []string{"id"}},
+			output: "MERGE INTO customer t USING (SELECT @p1 AS customer_id, @p2 AS name) s ON (t.customer_id = s.customer_id) " +
+				"WHEN MATCHED THEN UPDATE SET name = s.name WHEN NOT MATCHED THEN INSERT (customer_id, name) VALUES (s.customer_id, s.name);"},
+		{scenario: "postgres merge", input: JobOutput{Dialect: "postgres", TableName: "customer", Mode: "merge", KeyFields: []string{"id"}},
+			output: "MERGE INTO customer t USING (SELECT $1 AS customer_id, $2 AS name) s ON (t.customer_id = s.customer_id) " +
+				"WHEN MATCHED THEN UPDATE SET name = s.name WHEN NOT MATCHED THEN INSERT (customer_id, name) VALUES (s.customer_id, s.name)"},
+		{scenario: "sqlite update", input: JobOutput{Dialect: "sqlite", TableName: "customer", Mode: "update", KeyFields: []string{"id"}},
+			output: "UPDATE customer SET name = ? WHERE customer_id = ?"},
+	}
+
+	t.Run(">>> validation of SQL statement generation", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			test.input.FieldList = fieldList
+			testOutput := NewSQLTableOutput(test.input).(*sqlTableOutput)
+
+			got := testOutput.buildStatement()
+			want := test.output
+
+			if want != got {
+				t.Errorf("fail in buildStatement(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
+
+//	Test_SQLTableOutput_ImportData test cases for writing imported data into a table
+func Test_SQLTableOutput_ImportData(t *testing.T) {
+
+	t.Run(">>> validation of batched inserts", func(t *testing.T) {
+
+		const testFileName = "testData.txt"
+
+		err := os.WriteFile(testFileName, []byte("1,LINE#1\n2,LINE#2\n3,LINE#3\n"), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(testFileName)
+
+		fieldList := []DataField{
+			{Name: "test_1", Type: "integer"},
+			{Name: "test_2", Type: "string"},
+		}
+
+		testDatabase := &fakeDatabase{}
+		fakeDatabases["batch"] = testDatabase
+
+		testDataSource := NewCSVInputFile(JobInput{FileName: testFileName, FieldSeparator: ",", FieldList: fieldList})
+		testOutput := NewSQLTableOutput(JobOutput{Driver: "fake", DataSource: "batch", Dialect: "postgres", TableName: "test",
+			BatchSize: 2, FieldList: fieldList})
+
+		err = testOutput.Start(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Start(): %s", err)
+		}
+
+		_, err = testDataSource.ImportData(context.Background(), testOutput)
+		if err != nil {
+			t.Errorf("unexpected error in ImportData(): %s", err)
+		}
+
+		err = testOutput.Finish(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		if len(testDatabase.rows) != 3 {
+			t.Errorf("fail writing rows: expected: %d result: %d", 3, len(testDatabase.rows))
+		}
+		if testDatabase.rows[2][0] != int64(3) || testDatabase.rows[2][1] != "LINE#3" {
+			t.Errorf("fail writing row values: result: %v", testDatabase.rows[2])
+		}
+		if testDatabase.commits != 2 {
+			t.Errorf("fail committing batches: expected: %d result: %d", 2, testDatabase.commits)
+		}
+	})
+
+	t.Run(">>> validation of multiple rows inserts", func(t *testing.T) {
+
+		testDatabase := &fakeDatabase{}
+		fakeDatabases["multi"] = testDatabase
+
+		testOutput := NewSQLTableOutput(JobOutput{Driver: "fake", DataSource: "multi", Dialect: "sqlserver", TableName: "test",
+			Mode: "multi_insert", Transaction: "job", BatchSize: 2, FieldList: []DataField{{Name: "test_1", Type: "integer"}}})
+
+		err := testOutput.Start(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Start(): %s", err)
+		}
+
+		for i := 1; i <= 3; i++ {
+			_, err = testOutput.ProcessRow(newTestRow(map[string]string{"test_1": fmt.Sprint(i)}))
+			if err != nil {
+				t.Errorf("unexpected error in ProcessRow(): %s", err)
+			}
+		}
+
+		err = testOutput.Finish(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Finish(): %s", err)
+		}
+
+		want := "[INSERT INTO test (test_1) VALUES (@p1), (@p2) INSERT INTO test (test_1) VALUES (@p1)]"
+		if want != fmt.Sprint(testDatabase.statements) {
+			t.Errorf("fail writing multiple rows: expected: %s result: %v", want, testDatabase.statements)
+		}
+		if fmt.Sprint(testDatabase.rows) != "[[1 2] [3]]" || testDatabase.commits != 1 {
+			t.Errorf("fail writing multiple rows: rows: %v commits: %d", testDatabase.rows, testDatabase.commits)
+		}
+	})
+
+	t.Run(">>> validation of rollback for failed job", func(t *testing.T) {
+
+		testDatabase := &fakeDatabase{}
+		fakeDatabases["job"] = testDatabase
+
+		testOutput := NewSQLTableOutput(JobOutput{Driver: "fake", DataSource: "job", Dialect: "postgres", TableName: "test",
+			Transaction: "job", FieldList: []DataField{{Name: "test_1", Type: "string"}}})
+
+		err := testOutput.Start(nil)
+		if err != nil {
+			t.Errorf("unexpected error in Start(): %s", err)
+		}
+
+		_, err = testOutput.ProcessRow(newTestRow(map[string]string{"test_1": "LINE#1"}))
+		if err != nil {
+			t.Errorf("unexpected error in ProcessRow(): %s", err)
+		}
+
+		testOutput.Finish(errors.New("job failed"))
+
+		if testDatabase.commits != 0 || testDatabase.rollbacks != 1 {
+			t.Errorf("fail rolling back job: commits: %d rollbacks: %d", testDatabase.commits, testDatabase.rollbacks)
+		}
+	})
+
+	t.Run(">>> validation of commits with checkpoints", func(t *testing.T) {
+
+		const testFileName = "testData.txt"
+		const testStateName = "testState.json"
+
+		//	the batch size is smaller than the checkpoint interval, so a batch is full between two checkpoints
+		const config = `
+jobs:
+  - name: test
+    input:
+      type: CSVFile
+      file_name: testData.txt
+      field_separator: ","
+      fields:
+        - name: id
+          type: integer
+        - name: name
+          type: string
+    output:
+      type: SQLTable
+      driver: fake
+      data_source: checkpoint
+      dialect: postgres
+      table_name: test
+      batch_size: 2
+      fields:
+        - name: id
+          type: integer
+        - name: name
+          type: string
+    checkpoint:
+      file_name: testState.json
+      interval: 4
+`
+		var input strings.Builder
+		for i := 1; i <= 10; i++ {
+			fmt.Fprintf(&input, "%d,NAME#%d\n", i, i)
+		}
+
+		err := os.WriteFile(testFileName, []byte(input.String()), 0644)
+		if err != nil {
+			t.Errorf("unexpected error creating test file: %s", err)
+		}
+		defer os.Remove(testFileName)
+		defer os.Remove(testStateName)
+
+		dmig, err := LoadConfigFile(bufio.NewReader(strings.NewReader(config)))
+		if err != nil {
+			t.Errorf("unexpected error in LoadConfigFile(): %s", err)
+			return
+		}
+
+		committedIds := func(database *fakeDatabase) string {
+			var idList []string
+			for _, row := range database.committed {
+				idList = append(idList, fmt.Sprint(row[0]))
+			}
+			return strings.Join(idList, " ")
+		}
+
+		//	the job fails writing row 7, after the batch of rows 5 and 6 is full but before the next checkpoint
+		testDatabase := &fakeDatabase{failValue: int64(7)}
+		fakeDatabases["checkpoint"] = testDatabase
+
+		err = dmig.performJob(context.Background(), &dmig.JobList[0], &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "fake write error") {
+			t.Errorf("fail in performJob(): expected write error, result: %v", err)
+		}
+		if want, got := "1 2 3 4", committedIds(testDatabase); want != got {
+			t.Errorf("fail committing rows up to the checkpoint: expected: %s result: %s", want, got)
+		}
+
+		//	the resumed job writes the rows after the checkpoint only once
+		testDatabase.failValue = nil
+		dmig.Resume = true
+
+		err = dmig.performJob(context.Background(), &dmig.JobList[0], &bytes.Buffer{})
+		if err != nil {
+			t.Errorf("unexpected error in performJob(): %s", err)
+		}
+		if want, got := "1 2 3 4 5 6 7 8 9 10", committedIds(testDatabase); want != got {
+			t.Errorf("fail resuming from checkpoint: expected: %s result: %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+///////////////////////////////////////////////////////////////////////////////
+//	stepRegistry.go  -  Oct-19-2026  -  aldebap
+//
+//	Registry of pipeline step types that can be configured in a job
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"errors"
+	"strconv"
+)
+
+//	NewStepFunc create a pipeline step from its job configuration
+type NewStepFunc func(config JobStep, job *JobContext) (DataPipelineStep, error)
+
+var (
+	step_type = map[string]NewStepFunc{
+		"trace":     newTraceDataStepFromConfig,
+		"derive":    newDeriveStepFromConfig,
+		"filter":    newFilterStepFromConfig,
+		"lookup":    newLookupStepFromConfig,
+		"join":      newJoinStepFromConfig,
+		"sort":      newSortStepFromConfig,
+		"aggregate": newAggregateStepFromConfig,
+		"dedupe":    newDedupeStepFromConfig,
+	}
+)
+
+//	RegisterStepType register a new pipeline step type to be used in job configurations
+func RegisterStepType(stepType string, newStep NewStepFunc) error {
+
+	if len(stepType) == 0 || newStep == nil {
+		return errors.New("Invalid pipeline step registration")
+	}
+
+	_, found := step_type[stepType]
+	if found {
+		return errors.New("Pipeline step type already registered: " + stepType)
+	}
+
+	step_type[stepType] = newStep
+
+	return nil
+}
+
+//	newPipelineStep create a pipeline step from the step registry
+func newPipelineStep(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	newStep, found := step_type[config.Type]
+	if !found {
+		return nil, errors.New("Invalid pipeline step type: " + config.Type)
+	}
+
+	return newStep(config, job)
+}
+
+//	buildPipeline create the job's pipeline steps in order and chain them; the output sink,
+//	if given, is the last step; returns the first step in the pipeline
+func buildPipeline(job *JobContext, output DataOutputSink) (DataPipelineStep, error) {
+
+	var stepList []DataPipelineStep
+
+	if job.Job.Trace {
+		stepList = append(stepList, NewTraceDataStep(job.Job.Trace, job.Messages))
+	}
+
+	//	the input fields constraints are checked before any other step
+	for _, field := range job.Job.Input.FieldList {
+		if field.hasConstraints() {
+			step, err := NewFieldValidationStep(job.Job.Input.FieldList)
+			if err != nil {
+				return nil, err
+			}
+			stepList = append(stepList, step)
+			break
+		}
+	}
+
+	for i, config := range job.Job.Steps {
+		step, err := newPipelineStep(config, job)
+		if err != nil {
+			return nil, errors.New("fail creating pipeline step #" + strconv.Itoa(i+1) + ": " + err.Error())
+		}
+		stepList = append(stepList, step)
+	}
+
+	if output != nil {
+		stepList = append(stepList, output)
+	}
+
+	if len(stepList) == 0 {
+		return nil, nil
+	}
+
+	//	with more than one worker, the first sequence of steps that can run concurrently goes to a pool of workers
+	if job.Job.Workers < 0 {
+		return nil, errors.New("Invalid number of workers: " + strconv.Itoa(job.Job.Workers))
+	}
+	if job.Job.Workers > 1 {
+		var err error
+
+		stepList, err = parallelizeSteps(stepList, job.Job.Workers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 0; i < len(stepList)-1; i++ {
+		stepList[i].SetNextStep(stepList[i+1])
+	}
+
+	return stepList[0], nil
+}
+
+//	startPipeline start the pipeline steps in order; when a step fails to start, the steps already started are finished
+func startPipeline(firstStep DataPipelineStep, job *JobContext) error {
+
+	for step := firstStep; step != nil; step = step.GetNextStep() {
+		lifecycleStep, ok := step.(DataPipelineStepLifecycle)
+		if !ok {
+			continue
+		}
+
+		err := lifecycleStep.Start(job)
+		if err != nil {
+			finishSteps(firstStep, step, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+//	finishPipeline finish the pipeline steps in order, so rows sent by a step when it finishes still go through
+//	the steps after it; once a step fails, the next ones are finished with its error
+func finishPipeline(firstStep DataPipelineStep, jobErr error) error {
+
+	return finishSteps(firstStep, nil, jobErr)
+}
+
+//	drainPipeline wait for the rows still in the workers of the parallel steps to leave the pool; a fatal error
+//	in one of them is returned, as the rows after it were discarded
+func drainPipeline(firstStep DataPipelineStep) error {
+
+	for step := firstStep; step != nil; step = step.GetNextStep() {
+		parallel, ok := step.(*parallelStep)
+		if ok {
+			parallel.drain()
+
+			err := parallel.getError()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//	finishSteps finish the pipeline steps up to (not including) the last step
+func finishSteps(firstStep DataPipelineStep, lastStep DataPipelineStep, jobErr error) error {
+
+	var err error
+
+	for step := firstStep; step != nil && step != lastStep; step = step.GetNextStep() {
+		lifecycleStep, ok := step.(DataPipelineStepLifecycle)
+		if !ok {
+			continue
+		}
+
+		finishErr := lifecycleStep.Finish(jobErr)
+		if finishErr != nil && err == nil {
+			err = finishErr
+			if jobErr == nil {
+				jobErr = finishErr
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,256 @@
+///////////////////////////////////////////////////////////////////////////////
+//	stepRegistry_test.go  -  Oct-19-2026  -  aldebap
+//
+//	Unit tests for the registry of pipeline step types
+////////////////////////////////////////////////////////////////////////////////
+
+package migration
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//	test step that counts rows and tags them with a configured value
+type tagRowStep struct {
+	Tag   string `yaml:"tag"`
+	Count int
+
+	NextStep DataPipelineStep
+}
+
+func newTagRowStep(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	step := &tagRowStep{}
+
+	err := config.Decode(step)
+	if err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
+
+func (s *tagRowStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+func (s *tagRowStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+func (s *tagRowStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	s.Count++
+	row.Set("tag", s.Tag)
+
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+//	Test_StepRegistry_BuildPipeline test cases for building a pipeline from the job steps
+func Test_StepRegistry_BuildPipeline(t *testing.T) {
+
+	err := RegisterStepType("tag", newTagRowStep)
+	if err != nil {
+		t.Errorf("unexpected error in RegisterStepType(): %s", err)
+	}
+
+	t.Run(">>> validation of duplicated step type registration", func(t *testing.T) {
+
+		got := ""
+		want := "Pipeline step type already registered: tag"
+
+		err := RegisterStepType("tag", newTagRowStep)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in RegisterStepType(): expected: %s result: %s", want, got)
+		}
+	})
+
+	t.Run(">>> validation of pipeline built from job steps", func(t *testing.T) {
+
+		const config = `
+jobs:
+  - name: test
+    steps:
+      - type: tag
+        tag: first
+      - type: trace
+      - type: tag
+        tag: second
+`
+		dmig, err := LoadConfigFile(bufio.NewReader(strings.NewReader(config)))
+		if err != nil {
+			t.Errorf("unexpected error in LoadConfigFile(): %s", err)
+		}
+
+		messages := &bytes.Buffer{}
+		collector := &rowCollector{}
+
+		firstStep, err := buildPipeline(&JobContext{Job: &dmig.JobList[0], Messages: messages}, nil)
+		if err != nil {
+			t.Errorf("unexpected error in buildPipeline(): %s", err)
+		}
+
+		//	append a collector to the end of the pipeline
+		lastStep := firstStep
+		for lastStep.GetNextStep() != nil {
+			lastStep = lastStep.GetNextStep()
+		}
+		lastStep.SetNextStep(collector)
+
+		firstStep.ProcessRow(newTestRow(map[string]string{"test_1": "LINE#1"}))
+
+		if firstStep.(*tagRowStep).Tag != "first" || firstStep.(*tagRowStep).Count != 1 {
+			t.Errorf("fail in buildPipeline(): unexpected first step: %v", firstStep)
+		}
+		if len(collector.rows) != 1 || collector.rows[0].GetString("tag") != "second" {
+			t.Errorf("fail in buildPipeline(): unexpected rows: %v", collector.rows)
+		}
+		if !strings.HasPrefix(messages.String(), "[trace] fields: ") {
+			t.Errorf("fail in buildPipeline(): missing trace: %s", messages.String())
+		}
+	})
+
+	t.Run(">>> validation of invalid step type", func(t *testing.T) {
+
+		job := &MigrationJob{Steps: []JobStep{{Type: "xpto"}}}
+
+		got := ""
+		want := "fail creating pipeline step #1: Invalid pipeline step type: xpto"
+
+		_, err := buildPipeline(&JobContext{Job: job}, nil)
+		if err != nil {
+			got = err.Error()
+		}
+
+		if want != got {
+			t.Errorf("fail in buildPipeline(): expected: %s result: %s", want, got)
+		}
+	})
+}
+
+//	test step that records its lifecycle events
+type lifecycleRowStep struct {
+	Name      string
+	StartErr  error
+	FinishErr error
+
+	NextStep DataPipelineStep
+
+	events *[]string
+}
+
+func (s *lifecycleRowStep) SetNextStep(nextStep DataPipelineStep) {
+	s.NextStep = nextStep
+}
+
+func (s *lifecycleRowStep) GetNextStep() DataPipelineStep {
+	return s.NextStep
+}
+
+func (s *lifecycleRowStep) ProcessRow(row *Row) (rowProcessed bool, err error) {
+
+	if s.NextStep != nil {
+		return s.NextStep.ProcessRow(row)
+	}
+
+	return true, nil
+}
+
+func (s *lifecycleRowStep) Start(job *JobContext) error {
+
+	*s.events = append(*s.events, "start "+s.Name)
+
+	return s.StartErr
+}
+
+func (s *lifecycleRowStep) Finish(jobErr error) error {
+
+	event := "finish " + s.Name
+	if jobErr != nil {
+		event += " (" + jobErr.Error() + ")"
+	}
+	*s.events = append(*s.events, event)
+
+	return s.FinishErr
+}
+
+//	Test_StepRegistry_PipelineLifecycle test cases for starting and finishing the pipeline steps
+func Test_StepRegistry_PipelineLifecycle(t *testing.T) {
+
+	//	a few test cases
+	var testScenarios = []struct {
+		scenario  string
+		startErr  string
+		finishErr string
+		jobErr    string
+		output    string
+	}{
+		{scenario: "successful job", output: "start 1, start 2, start 3, finish 1, finish 2, finish 3"},
+		{scenario: "failed job", jobErr: "job failed",
+			output: "start 1, start 2, start 3, finish 1 (job failed), finish 2 (job failed), finish 3 (job failed): job failed"},
+		{scenario: "step fails to start", startErr: "start failed",
+			output: "start 1, start 2, finish 1 (start failed): start failed"},
+		{scenario: "step fails to finish", finishErr: "finish failed",
+			output: "start 1, start 2, start 3, finish 1, finish 2, finish 3 (finish failed): finish failed"},
+	}
+
+	t.Run(">>> validation of pipeline lifecycle", func(t *testing.T) {
+
+		for _, test := range testScenarios {
+
+			fmt.Printf("scenario: %s\n", test.scenario)
+
+			var events []string
+
+			stepList := []*lifecycleRowStep{{Name: "1"}, {Name: "2"}, {Name: "3"}}
+			for i, step := range stepList {
+				step.events = &events
+				if i > 0 {
+					stepList[i-1].SetNextStep(step)
+				}
+			}
+			if len(test.startErr) > 0 {
+				stepList[1].StartErr = errors.New(test.startErr)
+			}
+			if len(test.finishErr) > 0 {
+				stepList[1].FinishErr = errors.New(test.finishErr)
+			}
+
+			var jobErr error
+			if len(test.jobErr) > 0 {
+				jobErr = errors.New(test.jobErr)
+			}
+
+			err := startPipeline(stepList[0], nil)
+			if err == nil {
+				err = finishPipeline(stepList[0], jobErr)
+				if jobErr != nil {
+					err = jobErr
+				}
+			}
+
+			got := strings.Join(events, ", ")
+			if err != nil {
+				got += ": " + err.Error()
+			}
+			want := test.output
+
+			if want != got {
+				t.Errorf("fail in finishPipeline(): expected: %s result: %s", want, got)
+			}
+		}
+	})
+}
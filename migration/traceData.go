@@ -8,24 +8,37 @@ package migration
 
 import (
 	"fmt"
+	"io"
 	"os"
 )
 
 //	attributes for a trace
 type traceData struct {
-	Trace bool
+	Trace  bool
+	Output io.Writer
 
 	NextStep DataPipelineStep
 }
 
 //	NewTraceDataStep create a new traceDataPipelineStep
-func NewTraceDataStep(trace bool) DataPipelineStep {
+func NewTraceDataStep(trace bool, output io.Writer) DataPipelineStep {
+
+	if output == nil {
+		output = os.Stdout
+	}
 
 	return &traceData{
-		Trace: trace,
+		Trace:  trace,
+		Output: output,
 	}
 }
 
+//	newTraceDataStepFromConfig create a new traceDataPipelineStep from a job step configuration
+func newTraceDataStepFromConfig(config JobStep, job *JobContext) (DataPipelineStep, error) {
+
+	return NewTraceDataStep(true, job.Messages), nil
+}
+
 //	SetNextStep set the next step in data pipeline
 func (s *traceData) SetNextStep(nextStep DataPipelineStep) {
 	s.NextStep = nextStep
@@ -37,21 +50,10 @@ func (s *traceData) GetNextStep() DataPipelineStep {
 }
 
 //	ProcessRow generate a trace of the data row
-func (s *traceData) ProcessRow(row map[string]string) (rowProcessed bool, err error) {
+func (s *traceData) ProcessRow(row *Row) (rowProcessed bool, err error) {
 
 	if s.Trace {
-		var i uint
-
-		fmt.Fprintf(os.Stdout, "[trace] fields: ")
-		for fieldName, fieldValue := range row {
-			if i > 0 {
-				fmt.Fprintf(os.Stdout, "; ")
-			}
-			fmt.Fprintf(os.Stdout, "%s = '%s'", fieldName, fieldValue)
-
-			i++
-		}
-		fmt.Fprintf(os.Stdout, "\n")
+		fmt.Fprintf(s.Output, "[trace] fields: %s\n", row)
 	}
 
 	//	if available, invoke the next step in the pipeline